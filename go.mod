@@ -2,6 +2,4 @@ module github.com/gostdlib/types
 
 go 1.23.4
 
-toolchain go1.23.4
-
 require github.com/go-json-experiment/json v0.0.0-20241230001524-0240acd0e023
@@ -0,0 +1,420 @@
+package isset
+
+import (
+	"encoding"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"reflect"
+	"time"
+)
+
+// This file implements the encoding.BinaryMarshaler, encoding.BinaryAppender and encoding.BinaryUnmarshaler
+// interfaces with a compact format that is stable across releases and platforms. A value starts with a
+// presence byte, 0 if the value is unset and 1 if it is set, so an unset value is a single byte. A set
+// value is followed by:
+//
+//	Bool                 1 byte, 0 or 1
+//	Int8 ... Int64       1, 2, 4 or 8 bytes little endian, the sizes encoding/binary uses
+//	Uint8 ... Uint64     1, 2, 4 or 8 bytes little endian
+//	Int, Uint            8 bytes little endian, so the encoding does not depend on the platform
+//	Float32, Float64     the IEEE 754 bits in 4 or 8 bytes little endian
+//	Duration             8 bytes little endian
+//	String, Bytes        the length as a uvarint, followed by the bytes
+//	Time                 the encoding of time.Time.MarshalBinary
+//	Of[T]                the encoding of T.MarshalBinary
+//
+// A Nullable uses the presence byte 0 when absent, 1 when set, followed by the encoding of T.MarshalBinary,
+// and 2 when null. The value of Time, Of and Nullable is the rest of the data.
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+func (i Bool) MarshalBinary() ([]byte, error) {
+	return i.AppendBinary(make([]byte, 0, 2))
+}
+
+// AppendBinary implements the encoding.BinaryAppender interface.
+func (i Bool) AppendBinary(b []byte) ([]byte, error) {
+	switch {
+	case !i.isSet:
+		return append(b, 0), nil
+	case i.v:
+		return append(b, 1, 1), nil
+	}
+	return append(b, 1, 0), nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+func (i *Bool) UnmarshalBinary(data []byte) error {
+	b, set, err := splitBinary("isset.Bool", data, 1)
+	if err != nil || !set {
+		*i = i.Unset()
+		return err
+	}
+	if b[0] > 1 {
+		return fmt.Errorf("isset: cannot decode binary isset.Bool: invalid value %d", b[0])
+	}
+	*i = i.Set(b[0] == 1)
+	return nil
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+func (i String) MarshalBinary() ([]byte, error) {
+	return i.AppendBinary(make([]byte, 0, len(i.v)+1+binary.MaxVarintLen64))
+}
+
+// AppendBinary implements the encoding.BinaryAppender interface.
+func (i String) AppendBinary(b []byte) ([]byte, error) {
+	if !i.isSet {
+		return append(b, 0), nil
+	}
+	return append(binary.AppendUvarint(append(b, 1), uint64(len(i.v))), i.v...), nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+func (i *String) UnmarshalBinary(data []byte) error {
+	b, set, err := splitBinary("isset.String", data, lengthPrefixed)
+	if err != nil || !set {
+		*i = i.Unset()
+		return err
+	}
+	*i = i.Set(string(b))
+	return nil
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+func (i intType[T]) MarshalBinary() ([]byte, error) {
+	return i.AppendBinary(make([]byte, 0, 9))
+}
+
+// AppendBinary implements the encoding.BinaryAppender interface.
+func (i intType[T]) AppendBinary(b []byte) ([]byte, error) {
+	if !i.isSet {
+		return append(b, 0), nil
+	}
+	return appendFixed(append(b, 1), uint64(i.v), fixedSize[T]()), nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+func (i *intType[T]) UnmarshalBinary(data []byte) error {
+	b, set, err := splitBinary(numberTypeName[T](), data, fixedSize[T]())
+	if err != nil || !set {
+		*i = i.Unset()
+		return err
+	}
+	// Only an Int encoded on a 64-bit platform can be out of range.
+	n := readFixed(b)
+	if len(b) == 8 && int64(T(n)) != int64(n) {
+		return fmt.Errorf("isset: cannot decode binary %s: value %d out of range", numberTypeName[T](), int64(n))
+	}
+	*i = i.Set(T(n))
+	return nil
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+func (i uintType[T]) MarshalBinary() ([]byte, error) {
+	return i.AppendBinary(make([]byte, 0, 9))
+}
+
+// AppendBinary implements the encoding.BinaryAppender interface.
+func (i uintType[T]) AppendBinary(b []byte) ([]byte, error) {
+	if !i.isSet {
+		return append(b, 0), nil
+	}
+	return appendFixed(append(b, 1), uint64(i.v), fixedSize[T]()), nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+func (i *uintType[T]) UnmarshalBinary(data []byte) error {
+	b, set, err := splitBinary(numberTypeName[T](), data, fixedSize[T]())
+	if err != nil || !set {
+		*i = i.Unset()
+		return err
+	}
+	// Only a Uint encoded on a 64-bit platform can be out of range.
+	n := readFixed(b)
+	if uint64(T(n)) != n {
+		return fmt.Errorf("isset: cannot decode binary %s: value %d out of range", numberTypeName[T](), n)
+	}
+	*i = i.Set(T(n))
+	return nil
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+func (i floatType[T]) MarshalBinary() ([]byte, error) {
+	return i.AppendBinary(make([]byte, 0, 9))
+}
+
+// AppendBinary implements the encoding.BinaryAppender interface.
+func (i floatType[T]) AppendBinary(b []byte) ([]byte, error) {
+	if !i.isSet {
+		return append(b, 0), nil
+	}
+	if bitSize[T]() == 32 {
+		return binary.LittleEndian.AppendUint32(append(b, 1), math.Float32bits(float32(i.v))), nil
+	}
+	return binary.LittleEndian.AppendUint64(append(b, 1), math.Float64bits(float64(i.v))), nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+func (i *floatType[T]) UnmarshalBinary(data []byte) error {
+	b, set, err := splitBinary(numberTypeName[T](), data, bitSize[T]()/8)
+	if err != nil || !set {
+		*i = i.Unset()
+		return err
+	}
+	if len(b) == 4 {
+		*i = i.Set(T(math.Float32frombits(binary.LittleEndian.Uint32(b))))
+		return nil
+	}
+	*i = i.Set(T(math.Float64frombits(binary.LittleEndian.Uint64(b))))
+	return nil
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+func (i Time) MarshalBinary() ([]byte, error) {
+	return i.AppendBinary(make([]byte, 0, 16))
+}
+
+// AppendBinary implements the encoding.BinaryAppender interface.
+func (i Time) AppendBinary(b []byte) ([]byte, error) {
+	if !i.isSet {
+		return append(b, 0), nil
+	}
+	t, err := i.v.MarshalBinary()
+	if err != nil {
+		return b, err
+	}
+	return append(append(b, 1), t...), nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+func (i *Time) UnmarshalBinary(data []byte) error {
+	b, set, err := splitBinary("isset.Time", data, -1)
+	if err != nil || !set {
+		*i = i.Unset()
+		return err
+	}
+	var t time.Time
+	if err := t.UnmarshalBinary(b); err != nil {
+		return fmt.Errorf("isset: cannot decode binary isset.Time: %w", err)
+	}
+	*i = i.Set(t)
+	return nil
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+func (i Duration) MarshalBinary() ([]byte, error) {
+	return i.AppendBinary(make([]byte, 0, 9))
+}
+
+// AppendBinary implements the encoding.BinaryAppender interface.
+func (i Duration) AppendBinary(b []byte) ([]byte, error) {
+	if !i.isSet {
+		return append(b, 0), nil
+	}
+	return binary.LittleEndian.AppendUint64(append(b, 1), uint64(i.v)), nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+func (i *Duration) UnmarshalBinary(data []byte) error {
+	b, set, err := splitBinary("isset.Duration", data, 8)
+	if err != nil || !set {
+		*i = i.Unset()
+		return err
+	}
+	*i = i.Set(time.Duration(binary.LittleEndian.Uint64(b)))
+	return nil
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+func (i Bytes) MarshalBinary() ([]byte, error) {
+	return i.AppendBinary(make([]byte, 0, len(i.v)+1+binary.MaxVarintLen64))
+}
+
+// AppendBinary implements the encoding.BinaryAppender interface.
+func (i Bytes) AppendBinary(b []byte) ([]byte, error) {
+	if !i.isSet {
+		return append(b, 0), nil
+	}
+	return append(binary.AppendUvarint(append(b, 1), uint64(len(i.v))), i.v...), nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface. The bytes are copied.
+func (i *Bytes) UnmarshalBinary(data []byte) error {
+	b, set, err := splitBinary("isset.Bytes", data, lengthPrefixed)
+	if err != nil || !set {
+		*i = i.Unset()
+		return err
+	}
+	*i = i.Set(append([]byte{}, b...))
+	return nil
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface by delegating to T, which must implement
+// encoding.BinaryMarshaler.
+func (i Of[T]) MarshalBinary() ([]byte, error) {
+	return i.AppendBinary(nil)
+}
+
+// AppendBinary implements the encoding.BinaryAppender interface by delegating to T, which must implement
+// encoding.BinaryMarshaler.
+func (i Of[T]) AppendBinary(b []byte) ([]byte, error) {
+	if !i.isSet {
+		return append(b, 0), nil
+	}
+	return appendBinaryOf(append(b, 1), &i.v)
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface by delegating to T, which must
+// implement encoding.BinaryUnmarshaler.
+func (i *Of[T]) UnmarshalBinary(data []byte) error {
+	b, set, err := splitBinary(fmt.Sprintf("%T", *i), data, -1)
+	if err != nil || !set {
+		*i = i.Unset()
+		return err
+	}
+	var t T
+	if err := unmarshalBinaryOf(b, &t); err != nil {
+		return err
+	}
+	*i = i.Set(t)
+	return nil
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface by delegating to T, which must implement
+// encoding.BinaryMarshaler.
+func (i Nullable[T]) MarshalBinary() ([]byte, error) {
+	return i.AppendBinary(nil)
+}
+
+// AppendBinary implements the encoding.BinaryAppender interface by delegating to T, which must implement
+// encoding.BinaryMarshaler.
+func (i Nullable[T]) AppendBinary(b []byte) ([]byte, error) {
+	switch i.state {
+	case nullAbsent:
+		return append(b, 0), nil
+	case nullNull:
+		return append(b, 2), nil
+	}
+	return appendBinaryOf(append(b, 1), &i.v)
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface by delegating to T, which must
+// implement encoding.BinaryUnmarshaler.
+func (i *Nullable[T]) UnmarshalBinary(data []byte) error {
+	if len(data) == 1 && data[0] == 2 {
+		*i = i.SetNull()
+		return nil
+	}
+	b, set, err := splitBinary(fmt.Sprintf("%T", *i), data, -1)
+	if err != nil || !set {
+		*i = i.Unset()
+		return err
+	}
+	var t T
+	if err := unmarshalBinaryOf(b, &t); err != nil {
+		return err
+	}
+	*i = i.Set(t)
+	return nil
+}
+
+// binaryAppender is encoding.BinaryAppender, which was added in Go 1.24.
+type binaryAppender interface {
+	AppendBinary(b []byte) ([]byte, error)
+}
+
+// appendBinaryOf appends the binary encoding of *p to b with the binary methods of T.
+func appendBinaryOf[T any](b []byte, p *T) ([]byte, error) {
+	switch m := any(p).(type) {
+	case binaryAppender:
+		return m.AppendBinary(b)
+	case encoding.BinaryMarshaler:
+		data, err := m.MarshalBinary()
+		if err != nil {
+			return b, err
+		}
+		return append(b, data...), nil
+	}
+	return b, fmt.Errorf("%T does not implement encoding.BinaryMarshaler", *p)
+}
+
+// unmarshalBinaryOf decodes data into p with the encoding.BinaryUnmarshaler method of T.
+func unmarshalBinaryOf[T any](data []byte, p *T) error {
+	u, ok := any(p).(encoding.BinaryUnmarshaler)
+	if !ok {
+		return fmt.Errorf("%T does not implement encoding.BinaryUnmarshaler", *p)
+	}
+	return u.UnmarshalBinary(data)
+}
+
+// lengthPrefixed is the size passed to splitBinary for values that start with their length as a uvarint.
+const lengthPrefixed = -2
+
+// splitBinary checks the presence byte of the binary encoding of a typ in data and returns the encoded
+// value and if it is set. If size is not negative, the value must be size bytes long. If size is
+// lengthPrefixed, the value must be as long as the uvarint before it says.
+func splitBinary(typ string, data []byte, size int) ([]byte, bool, error) {
+	if len(data) == 0 {
+		return nil, false, fmt.Errorf("isset: cannot decode binary %s: no data", typ)
+	}
+	switch data[0] {
+	case 0:
+		if len(data) != 1 {
+			return nil, false, fmt.Errorf("isset: cannot decode binary %s: %d bytes after an unset value", typ, len(data)-1)
+		}
+		return nil, false, nil
+	case 1:
+		if size == lengthPrefixed {
+			n, l := binary.Uvarint(data[1:])
+			if l <= 0 {
+				return nil, false, fmt.Errorf("isset: cannot decode binary %s: invalid length", typ)
+			}
+			if b := data[1+l:]; uint64(len(b)) != n {
+				return nil, false, fmt.Errorf("isset: cannot decode binary %s: got %d bytes, want %d", typ, len(b), n)
+			}
+			return data[1+l:], true, nil
+		}
+		if size >= 0 && len(data)-1 != size {
+			return nil, false, fmt.Errorf("isset: cannot decode binary %s: got %d bytes, want %d", typ, len(data)-1, size)
+		}
+		return data[1:], true, nil
+	}
+	return nil, false, fmt.Errorf("isset: cannot decode binary %s: invalid presence byte %d", typ, data[0])
+}
+
+// fixedSize returns the number of bytes used for an integer of type T, which is 8 for int and uint.
+func fixedSize[T any]() int {
+	switch reflect.TypeFor[T]().Kind() {
+	case reflect.Int, reflect.Uint, reflect.Uintptr:
+		return 8
+	}
+	return bitSize[T]() / 8
+}
+
+// appendFixed appends the low size bytes of u to b in little endian order.
+func appendFixed(b []byte, u uint64, size int) []byte {
+	switch size {
+	case 1:
+		return append(b, byte(u))
+	case 2:
+		return binary.LittleEndian.AppendUint16(b, uint16(u))
+	case 4:
+		return binary.LittleEndian.AppendUint32(b, uint32(u))
+	}
+	return binary.LittleEndian.AppendUint64(b, u)
+}
+
+// readFixed reads a value written by appendFixed. Converting the result to the type that was written
+// gives the value, as the conversion keeps the low bytes.
+func readFixed(b []byte) uint64 {
+	switch len(b) {
+	case 1:
+		return uint64(b[0])
+	case 2:
+		return uint64(binary.LittleEndian.Uint16(b))
+	case 4:
+		return uint64(binary.LittleEndian.Uint32(b))
+	}
+	return binary.LittleEndian.Uint64(b)
+}
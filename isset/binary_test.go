@@ -0,0 +1,144 @@
+package isset
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/gob"
+	"math"
+	"net/netip"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type binaryMarshaler interface {
+	encoding.BinaryMarshaler
+	AppendBinary([]byte) ([]byte, error)
+}
+
+func TestBinary(t *testing.T) {
+	t.Parallel()
+
+	ts := time.Date(2025, 1, 2, 3, 4, 5, 6, time.UTC)
+	tsBin, _ := ts.MarshalBinary()
+	addr := netip.MustParseAddr("10.0.0.1")
+
+	tests := []struct {
+		name string
+		v    binaryMarshaler
+		got  encoding.BinaryUnmarshaler
+		want []byte
+	}{
+		{name: "Bool unset", v: Bool{}, got: &Bool{}, want: []byte{0}},
+		{name: "Bool false", v: Bool{}.Set(false), got: &Bool{}, want: []byte{1, 0}},
+		{name: "String", v: String{}.Set("hi"), got: &String{}, want: []byte{1, 2, 'h', 'i'}},
+		{name: "String empty", v: String{}.Set(""), got: &String{}, want: []byte{1, 0}},
+		{name: "Int8", v: Int8{}.Set(math.MinInt8), got: &Int8{}, want: []byte{1, 0x80}},
+		{name: "Int16", v: Int16{}.Set(-2), got: &Int16{}, want: []byte{1, 0xfe, 0xff}},
+		{name: "Int32 unset", v: Int32{}, got: &Int32{}, want: []byte{0}},
+		{name: "Int", v: Int{}.Set(-1), got: &Int{}, want: []byte{1, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}},
+		{name: "Uint32", v: Uint32{}.Set(0x01020304), got: &Uint32{}, want: []byte{1, 4, 3, 2, 1}},
+		{name: "Uint64", v: Uint64{}.Set(math.MaxUint64), got: &Uint64{}, want: []byte{1, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}},
+		{name: "Float32", v: Float32{}.Set(-2), got: &Float32{}, want: []byte{1, 0, 0, 0, 0xc0}},
+		{name: "Float64", v: Float64{}.Set(1), got: &Float64{}, want: []byte{1, 0, 0, 0, 0, 0, 0, 0xf0, 0x3f}},
+		{name: "Duration", v: Duration{}.Set(time.Second), got: &Duration{}, want: []byte{1, 0, 0xca, 0x9a, 0x3b, 0, 0, 0, 0}},
+		{name: "Time", v: Time{}.Set(ts), got: &Time{}, want: append([]byte{1}, tsBin...)},
+		{name: "Time unset", v: Time{}, got: &Time{}, want: []byte{0}},
+		{name: "Bytes", v: Bytes{}.Set([]byte{0, 1}), got: &Bytes{}, want: []byte{1, 2, 0, 1}},
+		{name: "Bytes empty", v: Bytes{}.Set([]byte{}), got: &Bytes{}, want: []byte{1, 0}},
+		{name: "Of", v: Of[netip.Addr]{}.Set(addr), got: &Of[netip.Addr]{}, want: []byte{1, 10, 0, 0, 1}},
+		{name: "Of unset", v: Of[netip.Addr]{}, got: &Of[netip.Addr]{}, want: []byte{0}},
+		{name: "Nullable", v: Nullable[netip.Addr]{}.Set(addr), got: &Nullable[netip.Addr]{}, want: []byte{1, 10, 0, 0, 1}},
+		{name: "Nullable null", v: Nullable[netip.Addr]{}.SetNull(), got: &Nullable[netip.Addr]{}, want: []byte{2}},
+		{name: "Nullable absent", v: Nullable[netip.Addr]{}, got: &Nullable[netip.Addr]{}, want: []byte{0}},
+	}
+
+	for _, tt := range tests {
+		b, err := tt.v.MarshalBinary()
+		if err != nil {
+			t.Errorf("TestBinary(%s): MarshalBinary() failed: %v", tt.name, err)
+			continue
+		}
+		if !bytes.Equal(b, tt.want) {
+			t.Errorf("TestBinary(%s): MarshalBinary() = %v, want %v", tt.name, b, tt.want)
+		}
+
+		a, err := tt.v.AppendBinary([]byte{9})
+		if err != nil || !bytes.Equal(a, append([]byte{9}, tt.want...)) {
+			t.Errorf("TestBinary(%s): AppendBinary() = %v, %v, want %v", tt.name, a, err, append([]byte{9}, tt.want...))
+		}
+
+		if err := tt.got.UnmarshalBinary(b); err != nil {
+			t.Errorf("TestBinary(%s): UnmarshalBinary() failed: %v", tt.name, err)
+			continue
+		}
+		if got := reflect.ValueOf(tt.got).Elem().Interface(); !reflect.DeepEqual(got, tt.v) {
+			t.Errorf("TestBinary(%s): UnmarshalBinary() = %+v, want %+v", tt.name, got, tt.v)
+		}
+	}
+}
+
+func TestBinaryErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		v    encoding.BinaryUnmarshaler
+		data []byte
+	}{
+		{name: "Duration short", v: &Duration{}, data: []byte{1, 0, 0}},
+		{name: "Time invalid", v: &Time{}, data: []byte{1, 0xff}},
+		{name: "Bytes invalid presence byte", v: &Bytes{}, data: []byte{2}},
+		{name: "Bytes missing length", v: &Bytes{}, data: []byte{1}},
+		{name: "Bytes short", v: &Bytes{}, data: []byte{1, 3, 0, 1}},
+		{name: "String trailing bytes", v: &String{}, data: []byte{1, 1, 'h', 'i'}},
+		{name: "Of invalid", v: &Of[netip.Addr]{}, data: []byte{1, 1, 2}},
+		{name: "Of without methods", v: &Of[point]{}, data: []byte{1, 1}},
+		{name: "Nullable invalid presence byte", v: &Nullable[netip.Addr]{}, data: []byte{3}},
+		{name: "Nullable data after null", v: &Nullable[netip.Addr]{}, data: []byte{2, 1}},
+	}
+
+	for _, tt := range tests {
+		if err := tt.v.UnmarshalBinary(tt.data); err == nil {
+			t.Errorf("TestBinaryErrors(%s): UnmarshalBinary(%v) succeeded, want error", tt.name, tt.data)
+		}
+	}
+
+	if _, err := (Of[point]{}).Set(point{}).MarshalBinary(); err == nil {
+		t.Errorf("TestBinaryErrors(Of without methods): MarshalBinary() succeeded, want error")
+	}
+}
+
+// TestBinaryGob checks that encoding/gob uses the binary encoding for the types without gob methods.
+func TestBinaryGob(t *testing.T) {
+	t.Parallel()
+
+	type snapshot struct {
+		Start   Time
+		Timeout Duration
+		Key     Bytes
+		Addr    Of[netip.Addr]
+		Parent  Nullable[netip.Addr]
+		Unset   Time
+	}
+
+	in := snapshot{
+		Start:   Time{}.Set(time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)),
+		Timeout: Duration{}.Set(0),
+		Key:     Bytes{}.Set([]byte{}),
+		Addr:    Of[netip.Addr]{}.Set(netip.MustParseAddr("::1")),
+		Parent:  Nullable[netip.Addr]{}.SetNull(),
+	}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(in); err != nil {
+		t.Fatalf("TestBinaryGob: Encode() failed: %v", err)
+	}
+	var got snapshot
+	if err := gob.NewDecoder(&buf).Decode(&got); err != nil {
+		t.Fatalf("TestBinaryGob: Decode() failed: %v", err)
+	}
+	if !reflect.DeepEqual(got, in) {
+		t.Errorf("TestBinaryGob: got %+v, want %+v", got, in)
+	}
+}
@@ -2,6 +2,7 @@ package isset
 
 import (
 	"fmt"
+	"strconv"
 
 	"github.com/go-json-experiment/json"
 	"github.com/go-json-experiment/json/jsontext"
@@ -23,6 +24,11 @@ func (i Bool) IsSet() bool {
 	return i.isSet
 }
 
+// IsZero reports if the value is unset.
+func (i Bool) IsZero() bool {
+	return !i.isSet
+}
+
 // Set sets the value and marks it as set.
 func (i Bool) Set(val bool) Bool {
 	i.v = val
@@ -39,36 +45,88 @@ func (i Bool) Unset() Bool {
 
 // MarshalJSON implements the json.Marshaler interface.
 func (i Bool) MarshalJSON() ([]byte, error) {
-	if !i.isSet {
-		return []byte{}, nil
+	return i.AppendJSON(make([]byte, 0, 5))
+}
+
+// AppendJSON appends the JSON encoding returned by MarshalJSON to dst. It lets encoders write the
+// value into their own buffer without allocating.
+func (i Bool) AppendJSON(dst []byte) ([]byte, error) {
+	return i.appendJSON(dst, getDefaults())
+}
+
+// appendJSON is AppendJSON with the options in o.
+func (i Bool) appendJSON(dst []byte, o *options) ([]byte, error) {
+	if o.writeNull(i.isSet) {
+		return append(dst, "null"...), nil
 	}
-	return json.Marshal(i.v)
+	return strconv.AppendBool(dst, i.v), nil
 }
 
 // MarshalJSONV2 implements the json.MarshalerV2 interface.
 func (i Bool) MarshalJSONV2(enc *jsontext.Encoder, opts json.Options) error {
+	return i.marshalJSONV2(enc, opts, getDefaults())
+}
+
+func (i Bool) marshalJSONV2(enc *jsontext.Encoder, opts json.Options, o *options) error {
+	if o.writeNull(i.isSet) {
+		return enc.WriteToken(jsontext.Null)
+	}
+	format, err := formatOf(opts, enc.StackDepth())
+	if err != nil {
+		return err
+	}
+	if format != "" {
+		return invalidFormatError("isset.Bool", format)
+	}
 	return enc.WriteToken(jsontext.Bool(bool(i.v)))
 }
 
 // UnmarshalJSON implements the json.Unmarshaler interface.
 func (i *Bool) UnmarshalJSON(data []byte) error {
-	if bytesToStr(data) == "null" {
+	return i.unmarshalJSON(data, getDefaults())
+}
+
+// unmarshalJSON is UnmarshalJSON with the options in o.
+func (i *Bool) unmarshalJSON(data []byte, o *options) error {
+	switch bytesToStr(data) {
+	case "null":
 		i.isSet = false
 		i.v = false
 		return nil
+	case "true", "false":
+		i.v = data[0] == 't'
+		i.isSet = true
+		return nil
 	}
 
-	i.isSet = true
-	var t bool
-	if err := json.Unmarshal(data, &t); err != nil {
-		return err
+	switch jsontext.Value(data).Kind() {
+	case 't', 'f':
+		return decodeErrorV1("isset.Bool", 't', data, fmt.Errorf("invalid literal %s", data))
+	case '"':
+		if o.lenient {
+			if err := unquoteV1(data, i.setLenient); err != nil {
+				return decodeErrorV1("isset.Bool", 't', data, err)
+			}
+			return nil
+		}
 	}
-	i.v = t
-	return nil
+	return decodeErrorV1("isset.Bool", 't', data, nil)
 }
 
 // UnmarshalJSONV2 implements the json.UnmarshalerV2 interface.
 func (v *Bool) UnmarshalJSONV2(dec *jsontext.Decoder, opts json.Options) error {
+	return v.unmarshalJSONV2(dec, opts, getDefaults())
+}
+
+func (v *Bool) unmarshalJSONV2(dec *jsontext.Decoder, opts json.Options, o *options) error {
+	format, err := formatOf(opts, dec.StackDepth())
+	if err != nil {
+		return err
+	}
+	if format != "" {
+		return invalidFormatError("isset.Bool", format)
+	}
+
 	t, err := dec.ReadToken()
 	if err != nil {
 		return err
@@ -83,6 +141,43 @@ func (v *Bool) UnmarshalJSONV2(dec *jsontext.Decoder, opts json.Options) error {
 		v.isSet = true
 		v.v = t.Bool()
 		return nil
+	case '"':
+		if o.lenient {
+			if err := v.setLenient(t.String()); err != nil {
+				return decodeError(dec, "isset.Bool", 't', '"', err)
+			}
+			return nil
+		}
 	}
-	return fmt.Errorf("expected a JSON bool, got %T", t.Kind())
+	return decodeError(dec, "isset.Bool", 't', t.Kind(), nil)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface. An unset value marshals to empty text.
+func (i Bool) MarshalText() ([]byte, error) {
+	return i.AppendText(nil)
+}
+
+// AppendText implements the encoding.TextAppender interface.
+func (i Bool) AppendText(b []byte) ([]byte, error) {
+	if !i.isSet {
+		return b, nil
+	}
+	return strconv.AppendBool(b, i.v), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface. It accepts the values
+// strconv.ParseBool accepts and marks the value as set on success. Empty text, which MarshalText
+// writes for an unset value, makes the value unset.
+func (i *Bool) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*i = i.Unset()
+		return nil
+	}
+	b, err := strconv.ParseBool(bytesToStr(text))
+	if err != nil {
+		return err
+	}
+	i.v = b
+	i.isSet = true
+	return nil
 }
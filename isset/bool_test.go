@@ -91,7 +91,7 @@ func TestBoolMarshalling(t *testing.T) {
 			jsonInput:  "",
 			wantBool:   false,
 			wantIsSet:  false,
-			wantOutput: "",
+			wantOutput: "null",
 		},
 		{
 			name:       "Unmarshal set Bool",
@@ -152,3 +152,59 @@ func TestBoolMarshalling(t *testing.T) {
 		}
 	}
 }
+
+func TestBoolText(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		text     string
+		want     Bool
+		wantErr  bool
+		wantText string
+	}{
+		{name: "true", text: "true", want: Bool{}.Set(true), wantText: "true"},
+		{name: "short false", text: "f", want: Bool{}.Set(false), wantText: "false"},
+		{name: "one", text: "1", want: Bool{}.Set(true), wantText: "true"},
+		{name: "empty is unset", text: ""},
+		{name: "yes", text: "yes", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		var v Bool
+		err := v.UnmarshalText([]byte(tt.text))
+		switch {
+		case err == nil && tt.wantErr:
+			t.Errorf("TestBoolText(%s): got err == nil, want err != nil", tt.name)
+			continue
+		case err != nil && !tt.wantErr:
+			t.Errorf("TestBoolText(%s): got err == %s, want err == nil", tt.name, err)
+			continue
+		case err != nil:
+			if v.IsSet() {
+				t.Errorf("TestBoolText(%s): IsSet() = true after error, want false", tt.name)
+			}
+			continue
+		}
+		if v != tt.want {
+			t.Errorf("TestBoolText(%s): got %v(set %v), want %v(set %v)", tt.name, v.V(), v.IsSet(), tt.want.V(), tt.want.IsSet())
+		}
+
+		text, err := v.MarshalText()
+		if err != nil {
+			t.Fatalf("TestBoolText(%s): MarshalText() failed: %v", tt.name, err)
+		}
+		if string(text) != tt.wantText {
+			t.Errorf("TestBoolText(%s): MarshalText() = %q, want %q", tt.name, text, tt.wantText)
+		}
+	}
+
+	text, err := Bool{}.MarshalText()
+	if err != nil || len(text) != 0 {
+		t.Errorf("TestBoolText(unset): MarshalText() = %q, %v, want empty text", text, err)
+	}
+	b, err := Bool{}.Set(true).AppendText([]byte("x="))
+	if err != nil || string(b) != "x=true" {
+		t.Errorf("TestBoolText(append): AppendText() = %q, %v, want %q", b, err, "x=true")
+	}
+}
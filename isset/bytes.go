@@ -0,0 +1,204 @@
+package isset
+
+import (
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/hex"
+
+	"github.com/go-json-experiment/json"
+	"github.com/go-json-experiment/json/jsontext"
+)
+
+// Bytes is a type representing a []byte that can be set or unset.
+// Setting an empty or nil slice marks the value as set, so Set([]byte{}) is distinguishable from an
+// unset value. The slice passed to Set is not copied.
+//
+// In JSON the value is encoded as a standard base64 string, like encoding/json does for []byte.
+// With the v2 json package the encoding can be changed with the `format` struct tag option,
+// which supports the same formats as a []byte: "base64", "base64url", "base32", "base32hex"
+// and "base16" (or "hex").
+type Bytes struct {
+	v     []byte
+	isSet bool
+}
+
+// V returns the value.
+func (i Bytes) V() []byte {
+	return i.v
+}
+
+// IsSet returns if the value was set.
+func (i Bytes) IsSet() bool {
+	return i.isSet
+}
+
+// IsZero reports if the value is unset.
+func (i Bytes) IsZero() bool {
+	return !i.isSet
+}
+
+// Set sets the value and marks it as set.
+func (i Bytes) Set(val []byte) Bytes {
+	i.v = val
+	i.isSet = true
+	return i
+}
+
+// Unset retuns the value to its zero value and marks it as unset.
+func (i Bytes) Unset() Bytes {
+	i.v = nil
+	i.isSet = false
+	return i
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (i Bytes) MarshalJSON() ([]byte, error) {
+	return i.AppendJSON(make([]byte, 0, base64.StdEncoding.EncodedLen(len(i.v))+2))
+}
+
+// AppendJSON appends the JSON encoding returned by MarshalJSON to dst. It lets encoders write the
+// value into their own buffer without allocating.
+func (i Bytes) AppendJSON(dst []byte) ([]byte, error) {
+	return i.appendJSON(dst, getDefaults())
+}
+
+// appendJSON is AppendJSON with the options in o.
+func (i Bytes) appendJSON(dst []byte, o *options) ([]byte, error) {
+	if o.writeNull(i.isSet) {
+		return append(dst, "null"...), nil
+	}
+	dst = append(dst, '"')
+	dst = base64.StdEncoding.AppendEncode(dst, i.v)
+	return append(dst, '"'), nil
+}
+
+// MarshalJSONV2 implements the json.MarshalerV2 interface.
+func (i Bytes) MarshalJSONV2(enc *jsontext.Encoder, opts json.Options) error {
+	return i.marshalJSONV2(enc, opts, getDefaults())
+}
+
+func (i Bytes) marshalJSONV2(enc *jsontext.Encoder, opts json.Options, o *options) error {
+	if o.writeNull(i.isSet) {
+		return enc.WriteToken(jsontext.Null)
+	}
+	format, err := formatOf(opts, enc.StackDepth())
+	if err != nil {
+		return err
+	}
+	codec, err := bytesCodecFor(format)
+	if err != nil {
+		return err
+	}
+	return enc.WriteToken(jsontext.String(bytesToStr(codec.encode(nil, i.v))))
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (i *Bytes) UnmarshalJSON(data []byte) error {
+	if bytesToStr(data) == "null" {
+		i.isSet = false
+		i.v = nil
+		return nil
+	}
+
+	if jsontext.Value(data).Kind() != '"' {
+		return decodeErrorV1("isset.Bytes", '"', data, nil)
+	}
+	s, err := unquote(data, false)
+	if err != nil {
+		return decodeErrorV1("isset.Bytes", '"', data, err)
+	}
+	b, err := base64.StdEncoding.AppendDecode([]byte{}, []byte(s))
+	if err != nil {
+		return decodeErrorV1("isset.Bytes", '"', data, err)
+	}
+	i.v = b
+	i.isSet = true
+	return nil
+}
+
+// UnmarshalJSONV2 implements the json.UnmarshalerV2 interface.
+func (v *Bytes) UnmarshalJSONV2(dec *jsontext.Decoder, opts json.Options) error {
+	format, err := formatOf(opts, dec.StackDepth())
+	if err != nil {
+		return err
+	}
+	codec, err := bytesCodecFor(format)
+	if err != nil {
+		return err
+	}
+
+	val, err := dec.ReadValue()
+	if err != nil {
+		return err
+	}
+
+	switch val.Kind() {
+	case 'n':
+		v.isSet = false
+		v.v = nil
+		return nil
+	case '"':
+		s, err := jsontext.AppendUnquote(nil, val)
+		if err != nil {
+			return decodeError(dec, "isset.Bytes", '"', '"', err)
+		}
+		b, err := codec.decode([]byte{}, s)
+		if err != nil {
+			return decodeError(dec, "isset.Bytes", '"', '"', err)
+		}
+		v.isSet = true
+		v.v = b
+		return nil
+	}
+	return decodeError(dec, "isset.Bytes", '"', val.Kind(), nil)
+}
+
+// bytesCodec converts between raw bytes and one of the textual encodings json v2 supports for []byte.
+type bytesCodec struct {
+	encode func(dst, src []byte) []byte
+	decode func(dst, src []byte) ([]byte, error)
+}
+
+// bytesCodecFor returns the bytesCodec for a json v2 `format` option. An empty format is base64.
+func bytesCodecFor(format string) (bytesCodec, error) {
+	switch format {
+	case "", "base64":
+		return bytesCodec{base64.StdEncoding.AppendEncode, base64.StdEncoding.AppendDecode}, nil
+	case "base64url":
+		return bytesCodec{base64.URLEncoding.AppendEncode, base64.URLEncoding.AppendDecode}, nil
+	case "base32":
+		return bytesCodec{base32.StdEncoding.AppendEncode, base32.StdEncoding.AppendDecode}, nil
+	case "base32hex":
+		return bytesCodec{base32.HexEncoding.AppendEncode, base32.HexEncoding.AppendDecode}, nil
+	case "base16", "hex":
+		return bytesCodec{hex.AppendEncode, hex.AppendDecode}, nil
+	}
+	return bytesCodec{}, invalidFormatError("isset.Bytes", format)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface. The value is encoded as standard base64.
+// An unset value marshals to empty text.
+func (i Bytes) MarshalText() ([]byte, error) {
+	return i.AppendText(nil)
+}
+
+// AppendText implements the encoding.TextAppender interface.
+func (i Bytes) AppendText(b []byte) ([]byte, error) {
+	if !i.isSet {
+		return b, nil
+	}
+	return base64.StdEncoding.AppendEncode(b, i.v), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface. It accepts standard base64 and marks
+// the value as set on success, even if the text is empty, so an unset Bytes, which MarshalText writes as
+// empty text, decodes as set and empty.
+func (i *Bytes) UnmarshalText(text []byte) error {
+	b, err := base64.StdEncoding.AppendDecode([]byte{}, text)
+	if err != nil {
+		return err
+	}
+	i.v = b
+	i.isSet = true
+	return nil
+}
@@ -0,0 +1,276 @@
+package isset
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/go-json-experiment/json"
+	"github.com/go-json-experiment/json/jsontext"
+)
+
+func TestBytes(t *testing.T) {
+	t.Parallel()
+
+	b := []byte("hello")
+
+	tests := []struct {
+		name      string
+		operation func() Bytes
+		wantBytes []byte
+		wantNil   bool
+		wantIsSet bool
+	}{
+		{
+			name: "Set Bytes",
+			operation: func() Bytes {
+				var v Bytes
+				return v.Set(b)
+			},
+			wantBytes: b,
+			wantIsSet: true,
+		},
+		{
+			name: "Unset Bytes",
+			operation: func() Bytes {
+				var v Bytes
+				v = v.Set(b)
+				return v.Unset()
+			},
+			wantBytes: nil, // Default zero Bytes
+			wantNil:   true,
+			wantIsSet: false,
+		},
+		{
+			name: "Default Bytes",
+			operation: func() Bytes {
+				return Bytes{}
+			},
+			wantBytes: nil, // Default zero Bytes
+			wantNil:   true,
+			wantIsSet: false,
+		},
+		{
+			name: "Set empty Bytes",
+			operation: func() Bytes {
+				var v Bytes
+				return v.Set([]byte{})
+			},
+			wantBytes: []byte{},
+			wantIsSet: true,
+		},
+	}
+
+	for _, tt := range tests {
+		v := tt.operation()
+		if got := v.V(); !bytes.Equal(got, tt.wantBytes) || (got == nil) != tt.wantNil {
+			t.Errorf("TestBytes(%s): V() = %#v, want %#v", tt.name, got, tt.wantBytes)
+		}
+		if got := v.IsSet(); got != tt.wantIsSet {
+			t.Errorf("TestBytes(%s): IsSet() = %v, want %v", tt.name, got, tt.wantIsSet)
+		}
+	}
+}
+
+func TestBytesMarshalling(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		initial    Bytes
+		jsonInput  string
+		wantBytes  []byte
+		wantIsSet  bool
+		wantOutput string
+		wantErr    bool
+	}{
+		{
+			name:       "Marshal set Bytes",
+			initial:    Bytes{}.Set([]byte("hello")),
+			wantOutput: `"aGVsbG8="`,
+		},
+		{
+			name:       "Marshal set empty Bytes",
+			initial:    Bytes{}.Set([]byte{}),
+			wantOutput: `""`,
+		},
+		{
+			name:       "Marshal unset Bytes",
+			initial:    Bytes{},
+			wantOutput: "null",
+		},
+		{
+			name:      "Unmarshal set Bytes",
+			jsonInput: `"aGVsbG8="`,
+			wantBytes: []byte("hello"),
+			wantIsSet: true,
+		},
+		{
+			name:      "Unmarshal empty Bytes",
+			jsonInput: `""`,
+			wantBytes: []byte{},
+			wantIsSet: true,
+		},
+		{
+			name:      "Unmarshal null Bytes",
+			jsonInput: "null",
+			wantIsSet: false,
+		},
+		{
+			name:      "Unmarshal invalid base64",
+			jsonInput: `"!!!"`,
+			wantErr:   true,
+		},
+		{
+			name:      "Unmarshal number Bytes",
+			jsonInput: "42",
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		if tt.jsonInput == "" {
+			// Test MarshalJSON
+			jsonBytes, err := tt.initial.MarshalJSON()
+			if err != nil {
+				t.Fatalf("TestMarshalling(%s) failed: %v", tt.name, err)
+			}
+			gotOutput := string(jsonBytes)
+			if gotOutput != tt.wantOutput {
+				t.Errorf("TestMarshalling(%s) = %v, want %v", tt.name, gotOutput, tt.wantOutput)
+			}
+			continue
+		}
+
+		// Test UnmarshalJSON
+		var v Bytes
+		err := v.UnmarshalJSON([]byte(tt.jsonInput))
+		switch {
+		case err == nil && tt.wantErr:
+			t.Errorf("TestMarshalling(%s)(v1): got err == nil, want err != nil", tt.name)
+		case err != nil && !tt.wantErr:
+			t.Errorf("TestMarshalling(%s)(v1): got err == %s, want err == nil", tt.name, err)
+		}
+		if got := v.V(); !bytes.Equal(got, tt.wantBytes) || (got == nil) != (tt.wantBytes == nil) {
+			t.Errorf("TestMarshalling(%s)(v1): V() = %#v, want %#v", tt.name, got, tt.wantBytes)
+		}
+		if got := v.IsSet(); got != tt.wantIsSet {
+			t.Errorf("TestMarshalling(%s)(v1): IsSet() = %v, want %v", tt.name, got, tt.wantIsSet)
+		}
+
+		// Test v2
+		var v2 Bytes
+		dec := jsontext.NewDecoder(bytes.NewReader([]byte(tt.jsonInput)))
+		err = v2.UnmarshalJSONV2(dec, json.DefaultOptionsV2())
+		switch {
+		case err == nil && tt.wantErr:
+			t.Errorf("TestMarshalling(%s)(v2): got err == nil, want err != nil", tt.name)
+		case err != nil && !tt.wantErr:
+			t.Errorf("TestMarshalling(%s)(v2): got err == %s, want err == nil", tt.name, err)
+		}
+		if got := v2.V(); !bytes.Equal(got, tt.wantBytes) || (got == nil) != (tt.wantBytes == nil) {
+			t.Errorf("TestMarshalling(%s)(v2): V() = %#v, want %#v", tt.name, got, tt.wantBytes)
+		}
+		if got := v2.IsSet(); got != tt.wantIsSet {
+			t.Errorf("TestMarshalling(%s)(v2): IsSet() = %v, want %v", tt.name, got, tt.wantIsSet)
+		}
+	}
+}
+
+func TestBytesFormat(t *testing.T) {
+	t.Parallel()
+
+	type config struct {
+		Default   Bytes
+		Hex       Bytes `json:",format:hex"`
+		Base32    Bytes `json:",format:base32"`
+		Base64URL Bytes `json:",format:base64url"`
+	}
+
+	key := []byte{0xfb, 0xff, 0x01}
+	want := `{"Default":"+/8B","Hex":"fbff01","Base32":"7P7QC===","Base64URL":"-_8B"}`
+
+	in := config{
+		Default:   Bytes{}.Set(key),
+		Hex:       Bytes{}.Set(key),
+		Base32:    Bytes{}.Set(key),
+		Base64URL: Bytes{}.Set(key),
+	}
+	got, err := json.Marshal(in)
+	if err != nil {
+		t.Fatalf("TestBytesFormat: Marshal() failed: %v", err)
+	}
+	if string(got) != want {
+		t.Errorf("TestBytesFormat: Marshal() = %s, want %s", got, want)
+	}
+
+	var out config
+	if err := json.Unmarshal([]byte(want), &out); err != nil {
+		t.Fatalf("TestBytesFormat: Unmarshal() failed: %v", err)
+	}
+	for _, v := range []Bytes{out.Default, out.Hex, out.Base32, out.Base64URL} {
+		if !v.IsSet() || !bytes.Equal(v.V(), key) {
+			t.Errorf("TestBytesFormat: Unmarshal() got %v(set %v), want %v", v.V(), v.IsSet(), key)
+		}
+	}
+
+	type badFormat struct {
+		V Bytes `json:",format:roman"`
+	}
+	if _, err := json.Marshal(badFormat{V: Bytes{}.Set(key)}); err == nil {
+		t.Errorf("TestBytesFormat: Marshal(unknown format) got err == nil, want err != nil")
+	}
+}
+
+func TestBytesText(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		text     string
+		want     Bytes
+		wantErr  bool
+		wantText string
+	}{
+		{name: "base64", text: "aGVsbG8=", want: Bytes{}.Set([]byte("hello")), wantText: "aGVsbG8="},
+		{name: "empty", text: "", want: Bytes{}.Set([]byte{}), wantText: ""},
+		{name: "bad base64", text: "aGVsbG8", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		var v Bytes
+		err := v.UnmarshalText([]byte(tt.text))
+		switch {
+		case err == nil && tt.wantErr:
+			t.Errorf("TestBytesText(%s): got err == nil, want err != nil", tt.name)
+			continue
+		case err != nil && !tt.wantErr:
+			t.Errorf("TestBytesText(%s): got err == %s, want err == nil", tt.name, err)
+			continue
+		case err != nil:
+			if v.IsSet() {
+				t.Errorf("TestBytesText(%s): IsSet() = true after error, want false", tt.name)
+			}
+			continue
+		}
+		if !bytes.Equal(v.V(), tt.want.V()) || v.V() == nil || v.IsSet() != tt.want.IsSet() {
+			t.Errorf("TestBytesText(%s): got %v(set %v), want %v(set %v)", tt.name, v.V(), v.IsSet(), tt.want.V(), tt.want.IsSet())
+		}
+
+		text, err := v.MarshalText()
+		if err != nil {
+			t.Fatalf("TestBytesText(%s): MarshalText() failed: %v", tt.name, err)
+		}
+		if string(text) != tt.wantText {
+			t.Errorf("TestBytesText(%s): MarshalText() = %q, want %q", tt.name, text, tt.wantText)
+		}
+	}
+
+	text, err := Bytes{}.MarshalText()
+	if err != nil || len(text) != 0 {
+		t.Errorf("TestBytesText(unset): MarshalText() = %q, %v, want empty text", text, err)
+	}
+	b, err := Bytes{}.Set([]byte("hi")).AppendText([]byte("x="))
+	if err != nil || string(b) != "x=aGk=" {
+		t.Errorf("TestBytesText(append): AppendText() = %q, %v, want %q", b, err, "x=aGk=")
+	}
+}
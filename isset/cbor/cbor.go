@@ -0,0 +1,122 @@
+/*
+Package cbor encodes and decodes CBOR (RFC 8949) for structs that contain isset types.
+
+It is a small codec without dependencies outside of the standard library that covers what is needed to
+send structs of isset fields over CBOR. A set value is encoded as its value and an unset value as null, or
+is omitted from the struct with the `omitempty` struct tag option. Decoding null or undefined makes the
+value unset and a missing field leaves the value unset:
+
+	type Reading struct {
+		Temp     isset.Float32 `cbor:"temp"`
+		Humidity isset.Uint8   `cbor:"hum,omitempty"` // Omitted when unset.
+		At       isset.Time                           // null when unset, the key is "At".
+	}
+
+	b, err := cbor.Marshal(r)
+	...
+	err = cbor.Unmarshal(b, &r)
+
+An isset.Nullable keeps its three states: null is encoded as null, absent as undefined (or omitted with
+`omitempty`) and decoding null or undefined makes it null or absent.
+
+Go values are mapped to CBOR as follows:
+
+	bool                    false and true
+	int*, uint*             unsigned or negative integer, in the shortest form that holds the value
+	float32, float64        the shortest of half, single and double precision that holds the value exactly
+	string                  text string
+	[]byte                  byte string
+	slices and arrays       array
+	maps                    map
+	structs                 map with the field names, or the name in the cbor struct tag, as keys
+	pointers, interfaces    the value they point to or hold, or null
+	time.Time               tag 1 with an integer for whole seconds, or else tag 0 with an RFC 3339 string
+	time.Duration           integer, in nanoseconds
+	isset types             the value V returns, or null when unset
+	isset.Of[netip.Addr]    byte string from MarshalBinary, or else text string from MarshalText, as for every
+	                        isset value that is a struct without exported fields, and an error if it has neither
+
+NaN is always encoded as the half precision quiet NaN. Decoding accepts every integer and float form,
+indefinite length strings, arrays and maps, and ignores tags other than 0 and 1. A time.Time is decoded from
+tag 0 or 1, or from an untagged text string or number, and epoch times are in UTC.
+
+When decoding into an interface, the values are nil, bool, int64, uint64 (only for values above
+math.MaxInt64), float64, string, []byte, []any, map[string]any and time.Time.
+
+Struct fields are matched by their exact name and unknown fields are skipped. Unexported fields and fields
+tagged with `cbor:"-"` are ignored. The `omitempty` option omits a field when it has its zero value as
+reported by reflect.Value.IsZero, which for the isset types means unset, or absent for an isset.Nullable.
+A set isset value is never encoded as null: a nil slice or map is written as an empty byte string, array
+or map, and a nil pointer or interface returns an error.
+*/
+package cbor
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Marshal returns the CBOR encoding of v.
+func Marshal(v any) ([]byte, error) {
+	if v == nil {
+		return []byte{simpleNull}, nil
+	}
+	rv := reflect.ValueOf(v)
+	return encoderFor(rv.Type())(nil, rv)
+}
+
+// Unmarshal decodes the CBOR data into the value v points to. The data must hold a single value, in
+// which arrays and maps are nested at most 10000 deep.
+func Unmarshal(data []byte, v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return fmt.Errorf("cbor: Unmarshal(non-pointer or nil %T)", v)
+	}
+	d := &decoder{data: data}
+	if err := decoderFor(rv.Type().Elem())(d, rv.Elem()); err != nil {
+		return err
+	}
+	if d.off != len(d.data) {
+		return fmt.Errorf("cbor: %d bytes after the value", len(d.data)-d.off)
+	}
+	return nil
+}
+
+// Major types. See RFC 8949 section 3.1.
+const (
+	majorUint   = 0
+	majorNegInt = 1
+	majorBytes  = 2
+	majorText   = 3
+	majorArray  = 4
+	majorMap    = 5
+	majorTag    = 6
+	majorSimple = 7
+)
+
+// Additional information values that are not lengths or values.
+const (
+	info8          = 24
+	info16         = 25
+	info32         = 26
+	info64         = 27
+	infoIndefinite = 31
+)
+
+// Encoded simple values and float heads.
+const (
+	simpleFalse     = 0xf4
+	simpleTrue      = 0xf5
+	simpleNull      = 0xf6
+	simpleUndefined = 0xf7
+	codeFloat16     = 0xf9
+	codeFloat32     = 0xfa
+	codeFloat64     = 0xfb
+	codeBreak       = 0xff
+)
+
+// Tags for date and time. See RFC 8949 section 3.4.
+const (
+	tagTimeString = 0
+	tagTimeEpoch  = 1
+)
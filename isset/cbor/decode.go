@@ -0,0 +1,908 @@
+package cbor
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"reflect"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gostdlib/types/isset/internal/codec"
+)
+
+// decoder reads CBOR values from data.
+type decoder struct {
+	data []byte
+	off  int
+	// depth is the number of arrays and maps that are being decoded.
+	depth int
+}
+
+// maxDepth is the maximum nesting of arrays and maps, the limit encoding/json uses. Deeper data returns an
+// error instead of overflowing the stack, which a program cannot recover from.
+const maxDepth = 10000
+
+// enter records that an array or map is decoded and returns an error if it is nested too deeply. leave
+// must be called when it is done.
+func (d *decoder) enter() error {
+	d.depth++
+	if d.depth > maxDepth {
+		return fmt.Errorf("cbor: exceeded max depth of %d at offset %d", maxDepth, d.off)
+	}
+	return nil
+}
+
+// leave records that an array or map passed to enter is decoded.
+func (d *decoder) leave() {
+	d.depth--
+}
+
+// head is the head of a CBOR value, after the tags that precede it. The data of a string and the
+// elements of an array or map follow it.
+type head struct {
+	major byte
+	// info is the additional information of the initial byte.
+	info byte
+	// u is the argument: the value of an integer, the length of a string, the number of elements of an
+	// array or map, the bits of a float or the simple value.
+	u uint64
+	// indefinite is true for a string, array or map of indefinite length.
+	indefinite bool
+	// tag is the last tag before the value and tagged is true if there was one.
+	tag    uint64
+	tagged bool
+}
+
+// String implements fmt.Stringer.
+func (h head) String() string {
+	switch h.major {
+	case majorUint:
+		return "unsigned integer"
+	case majorNegInt:
+		return "negative integer"
+	case majorBytes:
+		return "byte string"
+	case majorText:
+		return "text string"
+	case majorArray:
+		return "array"
+	case majorMap:
+		return "map"
+	}
+	switch {
+	case h.isFloat():
+		return "float"
+	case h.isBool():
+		return "bool"
+	case h.isNull():
+		return "null"
+	case h.u == simpleUndefined&0x1f:
+		return "undefined"
+	}
+	return fmt.Sprintf("simple value %d", h.u)
+}
+
+// isFloat reports if h is the head of a half, single or double precision float.
+func (h head) isFloat() bool {
+	return h.major == majorSimple && h.info >= info16 && h.info <= info64
+}
+
+// isBool reports if h is false or true.
+func (h head) isBool() bool {
+	return h.major == majorSimple && h.info < info8 && (h.u == simpleFalse&0x1f || h.u == simpleTrue&0x1f)
+}
+
+// isNull reports if h is null or undefined.
+func (h head) isNull() bool {
+	return h.major == majorSimple && h.info < info8 && (h.u == simpleNull&0x1f || h.u == simpleUndefined&0x1f)
+}
+
+// float returns the value of a float head.
+func (h head) float() float64 {
+	switch h.info {
+	case info16:
+		return float16Value(uint16(h.u))
+	case info32:
+		return float64(math.Float32frombits(uint32(h.u)))
+	}
+	return math.Float64frombits(h.u)
+}
+
+// float16Value returns the value of the IEEE 754 half precision bits h.
+func float16Value(h uint16) float64 {
+	exp, mant := int(h>>10&0x1f), float64(h&0x3ff)
+	var f float64
+	switch exp {
+	case 0:
+		f = math.Ldexp(mant, -24)
+	case 0x1f:
+		f = math.Inf(1)
+		if mant != 0 {
+			f = math.NaN()
+		}
+	default:
+		f = math.Ldexp(mant+1024, exp-25)
+	}
+	if h&0x8000 != 0 {
+		return -f
+	}
+	return f
+}
+
+// readHead reads the head of the next value, skipping the tags before it.
+func (d *decoder) readHead() (head, error) {
+	var h head
+	for {
+		if d.off >= len(d.data) {
+			return head{}, d.errEOF()
+		}
+		c := d.data[d.off]
+		d.off++
+		h.major, h.info = c>>5, c&0x1f
+
+		var err error
+		switch {
+		case h.info < info8:
+			h.u = uint64(h.info)
+		case h.info <= info64:
+			h.u, err = d.readUint(1 << (h.info - info8))
+			if err != nil {
+				return head{}, err
+			}
+		case h.info == infoIndefinite && h.major >= majorBytes && h.major <= majorMap:
+			h.indefinite = true
+		case c == codeBreak:
+			return head{}, fmt.Errorf("cbor: unexpected break at offset %d", d.off-1)
+		default:
+			return head{}, fmt.Errorf("cbor: invalid initial byte 0x%x at offset %d", c, d.off-1)
+		}
+
+		if h.major != majorTag {
+			return h, nil
+		}
+		h.tag, h.tagged = h.u, true
+	}
+}
+
+// readUint reads a big endian unsigned integer of size bytes.
+func (d *decoder) readUint(size int) (uint64, error) {
+	b, err := d.read(uint64(size))
+	if err != nil {
+		return 0, err
+	}
+	switch size {
+	case 1:
+		return uint64(b[0]), nil
+	case 2:
+		return uint64(binary.BigEndian.Uint16(b)), nil
+	case 4:
+		return uint64(binary.BigEndian.Uint32(b)), nil
+	}
+	return binary.BigEndian.Uint64(b), nil
+}
+
+// read returns the next n bytes. The slice points into the data.
+func (d *decoder) read(n uint64) ([]byte, error) {
+	if n > uint64(len(d.data)-d.off) {
+		return nil, d.errEOF()
+	}
+	b := d.data[d.off : d.off+int(n)]
+	d.off += int(n)
+	return b, nil
+}
+
+// readString reads the data of the byte or text string with the head h. The slice points into the data
+// unless the string has indefinite length.
+func (d *decoder) readString(h head) ([]byte, error) {
+	if !h.indefinite {
+		return d.read(h.u)
+	}
+	b := []byte{}
+	for {
+		more, err := d.more(h, 0)
+		if err != nil || !more {
+			return b, err
+		}
+		ch, err := d.readHead()
+		if err != nil {
+			return nil, err
+		}
+		if ch.major != h.major || ch.indefinite || ch.tagged {
+			return nil, fmt.Errorf("cbor: invalid %s chunk in a %s of indefinite length", ch, h)
+		}
+		p, err := d.read(ch.u)
+		if err != nil {
+			return nil, err
+		}
+		b = append(b, p...)
+	}
+}
+
+// readText reads the data of the text string with the head h and checks that it is valid UTF-8.
+func (d *decoder) readText(h head) ([]byte, error) {
+	b, err := d.readString(h)
+	if err != nil {
+		return nil, err
+	}
+	if !utf8.Valid(b) {
+		return nil, fmt.Errorf("cbor: invalid UTF-8 in text string")
+	}
+	return b, nil
+}
+
+// more reports if the array, map or string of indefinite length with the head h has another element
+// or chunk after the i that were read, and reads the break that ends it.
+func (d *decoder) more(h head, i uint64) (bool, error) {
+	if !h.indefinite {
+		return i < h.u, nil
+	}
+	if d.off >= len(d.data) {
+		return false, d.errEOF()
+	}
+	if d.data[d.off] == codeBreak {
+		d.off++
+		return false, nil
+	}
+	return true, nil
+}
+
+// peekNull reads the next value and returns its head if it is null or undefined. Otherwise it reads
+// nothing and returns ok false.
+func (d *decoder) peekNull() (h head, ok bool) {
+	if d.off < len(d.data) && (d.data[d.off] == simpleNull || d.data[d.off] == simpleUndefined) {
+		d.off++
+		return head{major: majorSimple, info: d.data[d.off-1] & 0x1f, u: uint64(d.data[d.off-1] & 0x1f)}, true
+	}
+	return head{}, false
+}
+
+// skip skips the rest of the value with the head h.
+func (d *decoder) skip(h head) error {
+	switch h.major {
+	case majorBytes, majorText:
+		_, err := d.readString(h)
+		return err
+	case majorArray, majorMap:
+		if err := d.enter(); err != nil {
+			return err
+		}
+		defer d.leave()
+		n := uint64(1)
+		if h.major == majorMap {
+			n = 2
+		}
+		for i := uint64(0); ; i++ {
+			more, err := d.more(h, i)
+			if err != nil || !more {
+				return err
+			}
+			for j := uint64(0); j < n; j++ {
+				eh, err := d.readHead()
+				if err != nil {
+					return err
+				}
+				if err := d.skip(eh); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (d *decoder) errEOF() error {
+	return fmt.Errorf("cbor: unexpected end of data at offset %d", len(d.data))
+}
+
+// typeError returns the error for the value with the head h that cannot be decoded into t.
+func typeError(h head, t reflect.Type) error {
+	return fmt.Errorf("cbor: cannot decode %s into %s", h, t)
+}
+
+// decodeFunc decodes the next value into v, which is settable.
+type decodeFunc func(d *decoder, v reflect.Value) error
+
+// decoders caches the decodeFunc for each reflect.Type.
+var decoders sync.Map
+
+// decoderFor returns the decodeFunc for t.
+func decoderFor(t reflect.Type) decodeFunc {
+	if f, ok := decoders.Load(t); ok {
+		return f.(decodeFunc)
+	}
+
+	// See encoderFor.
+	var (
+		wg sync.WaitGroup
+		f  decodeFunc
+	)
+	wg.Add(1)
+	fi, loaded := decoders.LoadOrStore(t, decodeFunc(func(d *decoder, v reflect.Value) error {
+		wg.Wait()
+		return f(d, v)
+	}))
+	if loaded {
+		return fi.(decodeFunc)
+	}
+	f = newDecoder(t)
+	wg.Done()
+	decoders.Store(t, f)
+	return f
+}
+
+// newDecoder builds the decodeFunc for t.
+func newDecoder(t reflect.Type) decodeFunc {
+	if t == timeType {
+		return decodeTime
+	}
+	if m, ok := codec.IssetOf(t); ok {
+		return newIssetDecoder(m)
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return decodeBool
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return decodeInt
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return decodeUint
+	case reflect.Float32, reflect.Float64:
+		return decodeFloat
+	case reflect.String:
+		return decodeString
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return decodeBytes
+		}
+		return newSliceDecoder(t)
+	case reflect.Array:
+		return newArrayDecoder(t)
+	case reflect.Map:
+		return newMapDecoder(t)
+	case reflect.Struct:
+		return newStructDecoder(t)
+	case reflect.Pointer:
+		return newPointerDecoder(t)
+	case reflect.Interface:
+		if t.NumMethod() == 0 {
+			return decodeInterface
+		}
+	}
+	return func(d *decoder, v reflect.Value) error {
+		return fmt.Errorf("cbor: unsupported type %s", t)
+	}
+}
+
+// readNonNull reads the head of the next value. A null or undefined sets v to its zero value and returns
+// ok false.
+func (d *decoder) readNonNull(v reflect.Value) (h head, ok bool, err error) {
+	h, err = d.readHead()
+	if err != nil {
+		return h, false, err
+	}
+	if h.isNull() {
+		v.SetZero()
+		return h, false, nil
+	}
+	return h, true, nil
+}
+
+func decodeBool(d *decoder, v reflect.Value) error {
+	h, ok, err := d.readNonNull(v)
+	if err != nil || !ok {
+		return err
+	}
+	if !h.isBool() {
+		return typeError(h, v.Type())
+	}
+	v.SetBool(h.u == simpleTrue&0x1f)
+	return nil
+}
+
+func decodeInt(d *decoder, v reflect.Value) error {
+	h, ok, err := d.readNonNull(v)
+	if err != nil || !ok {
+		return err
+	}
+	if h.major != majorUint && h.major != majorNegInt {
+		return typeError(h, v.Type())
+	}
+	i, ok := h.int()
+	if !ok || v.OverflowInt(i) {
+		return fmt.Errorf("cbor: %s %d overflows %s", h, h.u, v.Type())
+	}
+	v.SetInt(i)
+	return nil
+}
+
+// int returns the value of an integer head and if it fits in an int64.
+func (h head) int() (int64, bool) {
+	if h.u > math.MaxInt64 {
+		return 0, false
+	}
+	if h.major == majorNegInt {
+		return -1 - int64(h.u), true
+	}
+	return int64(h.u), true
+}
+
+func decodeUint(d *decoder, v reflect.Value) error {
+	h, ok, err := d.readNonNull(v)
+	if err != nil || !ok {
+		return err
+	}
+	switch h.major {
+	case majorNegInt:
+		return fmt.Errorf("cbor: negative integer for %s", v.Type())
+	case majorUint:
+	default:
+		return typeError(h, v.Type())
+	}
+	if v.OverflowUint(h.u) {
+		return fmt.Errorf("cbor: value %d overflows %s", h.u, v.Type())
+	}
+	v.SetUint(h.u)
+	return nil
+}
+
+// decodeFloat decodes a float or an integer into a float32 or float64.
+func decodeFloat(d *decoder, v reflect.Value) error {
+	h, ok, err := d.readNonNull(v)
+	if err != nil || !ok {
+		return err
+	}
+	var f float64
+	switch {
+	case h.isFloat():
+		f = h.float()
+	case h.major == majorUint:
+		f = float64(h.u)
+	case h.major == majorNegInt:
+		f = -1 - float64(h.u)
+	default:
+		return typeError(h, v.Type())
+	}
+	if v.Kind() == reflect.Float32 && h.info == info64 && v.OverflowFloat(f) {
+		return fmt.Errorf("cbor: value %v overflows %s", f, v.Type())
+	}
+	v.SetFloat(f)
+	return nil
+}
+
+// decodeString decodes a text or byte string into a string.
+func decodeString(d *decoder, v reflect.Value) error {
+	h, ok, err := d.readNonNull(v)
+	if err != nil || !ok {
+		return err
+	}
+	var b []byte
+	switch h.major {
+	case majorText:
+		b, err = d.readText(h)
+	case majorBytes:
+		b, err = d.readString(h)
+	default:
+		return typeError(h, v.Type())
+	}
+	if err != nil {
+		return err
+	}
+	v.SetString(string(b))
+	return nil
+}
+
+// decodeBytes decodes a byte or text string into a byte slice, which does not point into the data.
+func decodeBytes(d *decoder, v reflect.Value) error {
+	h, ok, err := d.readNonNull(v)
+	if err != nil || !ok {
+		return err
+	}
+	if h.major != majorBytes && h.major != majorText {
+		return typeError(h, v.Type())
+	}
+	b, err := d.readString(h)
+	if err != nil {
+		return err
+	}
+	v.SetBytes(append(make([]byte, 0, len(b)), b...))
+	return nil
+}
+
+func decodeTime(d *decoder, v reflect.Value) error {
+	h, ok, err := d.readNonNull(v)
+	if err != nil || !ok {
+		return err
+	}
+	t, err := d.readTime(h, v.Type())
+	if err != nil {
+		return err
+	}
+	v.Set(reflect.ValueOf(t))
+	return nil
+}
+
+// readTime reads a time from a text string in RFC 3339 format or from seconds since the epoch, which
+// may be tagged with tag 0 or 1. Epoch times are in UTC. t is the type used in errors.
+func (d *decoder) readTime(h head, t reflect.Type) (time.Time, error) {
+	switch {
+	case h.major == majorText && (!h.tagged || h.tag == tagTimeString):
+		b, err := d.readText(h)
+		if err != nil {
+			return time.Time{}, err
+		}
+		var tm time.Time
+		if err := tm.UnmarshalText(b); err != nil {
+			return time.Time{}, fmt.Errorf("cbor: %w", err)
+		}
+		return tm, nil
+	case h.tagged && h.tag != tagTimeEpoch:
+	case h.major == majorUint || h.major == majorNegInt:
+		sec, ok := h.int()
+		if !ok {
+			return time.Time{}, fmt.Errorf("cbor: epoch time %s %d overflows int64", h, h.u)
+		}
+		return time.Unix(sec, 0).UTC(), nil
+	case h.isFloat():
+		f := h.float()
+		if math.IsNaN(f) || math.IsInf(f, 0) || math.Abs(f) >= 1<<63 {
+			return time.Time{}, fmt.Errorf("cbor: invalid epoch time %v", f)
+		}
+		sec, frac := math.Modf(f)
+		return time.Unix(int64(sec), int64(frac*1e9)).UTC(), nil
+	}
+	if h.tagged {
+		return time.Time{}, fmt.Errorf("cbor: cannot decode %s with tag %d into %s", h, h.tag, t)
+	}
+	return time.Time{}, typeError(h, t)
+}
+
+func decodeInterface(d *decoder, v reflect.Value) error {
+	h, err := d.readHead()
+	if err != nil {
+		return err
+	}
+	a, err := d.decodeAny(h)
+	if err != nil {
+		return err
+	}
+	if a == nil {
+		v.SetZero()
+		return nil
+	}
+	v.Set(reflect.ValueOf(a))
+	return nil
+}
+
+// decodeAny decodes the value with the head h into the Go value used for an interface.
+func (d *decoder) decodeAny(h head) (any, error) {
+	if h.tagged && (h.tag == tagTimeString || h.tag == tagTimeEpoch) {
+		return d.readTime(h, timeType)
+	}
+
+	switch h.major {
+	case majorUint:
+		if h.u > math.MaxInt64 {
+			return h.u, nil
+		}
+		return int64(h.u), nil
+	case majorNegInt:
+		i, ok := h.int()
+		if !ok {
+			return nil, fmt.Errorf("cbor: %s %d overflows int64", h, h.u)
+		}
+		return i, nil
+	case majorBytes:
+		b, err := d.readString(h)
+		return append([]byte{}, b...), err
+	case majorText:
+		b, err := d.readText(h)
+		return string(b), err
+	case majorArray:
+		if err := d.enter(); err != nil {
+			return nil, err
+		}
+		defer d.leave()
+		a := make([]any, 0, min(h.u, uint64(len(d.data)-d.off)))
+		for i := uint64(0); ; i++ {
+			more, err := d.more(h, i)
+			if err != nil {
+				return nil, err
+			}
+			if !more {
+				return a, nil
+			}
+			eh, err := d.readHead()
+			if err != nil {
+				return nil, err
+			}
+			e, err := d.decodeAny(eh)
+			if err != nil {
+				return nil, err
+			}
+			a = append(a, e)
+		}
+	case majorMap:
+		if err := d.enter(); err != nil {
+			return nil, err
+		}
+		defer d.leave()
+		m := make(map[string]any, min(h.u, uint64(len(d.data)-d.off)))
+		for i := uint64(0); ; i++ {
+			more, err := d.more(h, i)
+			if err != nil {
+				return nil, err
+			}
+			if !more {
+				return m, nil
+			}
+			kh, err := d.readHead()
+			if err != nil {
+				return nil, err
+			}
+			if kh.major != majorText {
+				return nil, fmt.Errorf("cbor: cannot decode a map with %s keys into an interface", kh)
+			}
+			k, err := d.readText(kh)
+			if err != nil {
+				return nil, err
+			}
+			eh, err := d.readHead()
+			if err != nil {
+				return nil, err
+			}
+			if m[string(k)], err = d.decodeAny(eh); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	switch {
+	case h.isNull():
+		return nil, nil
+	case h.isBool():
+		return h.u == simpleTrue&0x1f, nil
+	case h.isFloat():
+		return h.float(), nil
+	}
+	return nil, fmt.Errorf("cbor: unsupported %s", h)
+}
+
+func newPointerDecoder(t reflect.Type) decodeFunc {
+	elem := decoderFor(t.Elem())
+	return func(d *decoder, v reflect.Value) error {
+		if _, ok := d.peekNull(); ok {
+			v.SetZero()
+			return nil
+		}
+		if v.IsNil() {
+			v.Set(reflect.New(t.Elem()))
+		}
+		return elem(d, v.Elem())
+	}
+}
+
+func newSliceDecoder(t reflect.Type) decodeFunc {
+	elem := decoderFor(t.Elem())
+	return func(d *decoder, v reflect.Value) error {
+		h, ok, err := d.readNonNull(v)
+		if err != nil || !ok {
+			return err
+		}
+		if h.major != majorArray {
+			return typeError(h, t)
+		}
+		if err := d.enter(); err != nil {
+			return err
+		}
+		defer d.leave()
+		// Each element is at least one byte, so a corrupt length cannot allocate more than the data.
+		if h.u > uint64(len(d.data)-d.off) {
+			return d.errEOF()
+		}
+		s := reflect.MakeSlice(t, int(h.u), int(h.u))
+		for i := 0; ; i++ {
+			more, err := d.more(h, uint64(i))
+			if err != nil {
+				return err
+			}
+			if !more {
+				break
+			}
+			if h.indefinite {
+				s = reflect.Append(s, reflect.Zero(t.Elem()))
+			}
+			if err := elem(d, s.Index(i)); err != nil {
+				return err
+			}
+		}
+		v.Set(s)
+		return nil
+	}
+}
+
+func newArrayDecoder(t reflect.Type) decodeFunc {
+	elem := decoderFor(t.Elem())
+	return func(d *decoder, v reflect.Value) error {
+		h, ok, err := d.readNonNull(v)
+		if err != nil || !ok {
+			return err
+		}
+		if h.major != majorArray || (!h.indefinite && h.u != uint64(t.Len())) {
+			return fmt.Errorf("cbor: cannot decode %s of length %d into %s", h, h.u, t)
+		}
+		if err := d.enter(); err != nil {
+			return err
+		}
+		defer d.leave()
+		for i := 0; ; i++ {
+			more, err := d.more(h, uint64(i))
+			if err != nil {
+				return err
+			}
+			if !more {
+				if i != t.Len() {
+					return fmt.Errorf("cbor: cannot decode %s of length %d into %s", h, i, t)
+				}
+				return nil
+			}
+			if i == t.Len() {
+				return fmt.Errorf("cbor: cannot decode %s of more than %d elements into %s", h, i, t)
+			}
+			if err := elem(d, v.Index(i)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func newMapDecoder(t reflect.Type) decodeFunc {
+	key, elem := decoderFor(t.Key()), decoderFor(t.Elem())
+	return func(d *decoder, v reflect.Value) error {
+		h, ok, err := d.readNonNull(v)
+		if err != nil || !ok {
+			return err
+		}
+		if h.major != majorMap {
+			return typeError(h, t)
+		}
+		if err := d.enter(); err != nil {
+			return err
+		}
+		defer d.leave()
+		if h.u > uint64(len(d.data)-d.off) {
+			return d.errEOF()
+		}
+		if v.IsNil() {
+			v.Set(reflect.MakeMapWithSize(t, int(h.u)))
+		}
+		for i := uint64(0); ; i++ {
+			more, err := d.more(h, i)
+			if err != nil || !more {
+				return err
+			}
+			k := reflect.New(t.Key()).Elem()
+			if err := key(d, k); err != nil {
+				return err
+			}
+			e := reflect.New(t.Elem()).Elem()
+			if err := elem(d, e); err != nil {
+				return err
+			}
+			v.SetMapIndex(k, e)
+		}
+	}
+}
+
+func newStructDecoder(t reflect.Type) decodeFunc {
+	fields := codec.StructFields(t, "cbor")
+	byName := make(map[string]int, len(fields))
+	decs := make([]decodeFunc, len(fields))
+	for i, f := range fields {
+		byName[f.Name] = i
+		decs[i] = decoderFor(f.Type)
+	}
+	return func(d *decoder, v reflect.Value) error {
+		h, ok, err := d.readNonNull(v)
+		if err != nil || !ok {
+			return err
+		}
+		if h.major != majorMap {
+			return typeError(h, t)
+		}
+		if err := d.enter(); err != nil {
+			return err
+		}
+		defer d.leave()
+		for n := uint64(0); ; n++ {
+			more, err := d.more(h, n)
+			if err != nil || !more {
+				return err
+			}
+			kh, err := d.readHead()
+			if err != nil {
+				return err
+			}
+			if kh.major != majorText {
+				return fmt.Errorf("cbor: cannot decode a map with %s keys into %s", kh, t)
+			}
+			name, err := d.readString(kh)
+			if err != nil {
+				return err
+			}
+			i, ok := byName[string(name)]
+			if !ok {
+				eh, err := d.readHead()
+				if err != nil {
+					return err
+				}
+				if err := d.skip(eh); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := decs[i](d, v.Field(fields[i].Index)); err != nil {
+				return fmt.Errorf("%w (field %s.%s)", err, t, fields[i].Name)
+			}
+		}
+	}
+}
+
+// newIssetDecoder returns a decodeFunc that sets the value of an isset type. A null or undefined makes it
+// unset. An isset.Nullable is made null by null and absent by undefined.
+func newIssetDecoder(m codec.Isset) decodeFunc {
+	elem := newElemDecoder(m.Elem)
+	return func(d *decoder, v reflect.Value) error {
+		if h, ok := d.peekNull(); ok {
+			if h.u == simpleNull&0x1f {
+				m.Null(v)
+			} else {
+				m.Unset(v)
+			}
+			return nil
+		}
+		e := reflect.New(m.Elem).Elem()
+		if err := elem(d, e); err != nil {
+			return err
+		}
+		m.Set(v, e)
+		return nil
+	}
+}
+
+// newElemDecoder returns the decodeFunc for the value of an isset type. A struct without exported
+// fields, such as netip.Addr, is decoded from a byte string with its encoding.BinaryUnmarshaler method
+// or else from a text string with its encoding.TextUnmarshaler method.
+func newElemDecoder(t reflect.Type) decodeFunc {
+	switch method := codec.MethodOf(t); method {
+	case codec.MethodBinary, codec.MethodText:
+		return func(d *decoder, v reflect.Value) error {
+			h, err := d.readHead()
+			if err != nil {
+				return err
+			}
+			var b []byte
+			switch {
+			case method == codec.MethodBinary && h.major == majorBytes:
+				b, err = d.readString(h)
+			case method == codec.MethodText && h.major == majorText:
+				b, err = d.readText(h)
+			default:
+				return typeError(h, t)
+			}
+			if err != nil {
+				return err
+			}
+			if err := method.Unmarshal(b, v); err != nil {
+				return fmt.Errorf("cbor: cannot decode %s: %w", t, err)
+			}
+			return nil
+		}
+	case codec.MethodMissing:
+		return func(d *decoder, v reflect.Value) error {
+			return fmt.Errorf("cbor: cannot decode %s: it has no exported fields and does not implement encoding.BinaryUnmarshaler or encoding.TextUnmarshaler", t)
+		}
+	}
+	return decoderFor(t)
+}
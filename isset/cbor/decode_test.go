@@ -0,0 +1,179 @@
+package cbor
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDecode(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		data string
+		into any
+		want any
+	}{
+		{name: "uint16 into int8", data: "190007", into: new(int8), want: int8(7)},
+		{name: "uint64 into uint", data: "1b0000000000000007", into: new(uint), want: uint(7)},
+		{name: "negative", data: "3903e7", into: new(int16), want: int16(-1000)},
+		{name: "min int64", data: "3b7fffffffffffffff", into: new(int64), want: int64(math.MinInt64)},
+		{name: "int into float", data: "3863", into: new(float64), want: -100.0},
+		{name: "half into float32", data: "f93e00", into: new(float32), want: float32(1.5)},
+		{name: "double into float32", data: "fb3ff8000000000000", into: new(float32), want: float32(1.5)},
+		{name: "single into float64", data: "fa47c35000", into: new(float64), want: 100000.0},
+		{name: "text", data: "62c3bc", into: new(string), want: "ü"},
+		{name: "bytes into string", data: "4161", into: new(string), want: "a"},
+		{name: "text into bytes", data: "6161", into: new([]byte), want: []byte("a")},
+		{name: "indefinite bytes", data: "5f42010243030405ff", into: new([]byte), want: []byte{1, 2, 3, 4, 5}},
+		{name: "indefinite text", data: "7f657374726561646d696e67ff", into: new(string), want: "streaming"},
+		{name: "empty indefinite array", data: "9fff", into: new([]int), want: []int{}},
+		{name: "indefinite array", data: "9f018202039f0405ffff", into: new([]any), want: []any{int64(1), []any{int64(2), int64(3)}, []any{int64(4), int64(5)}}},
+		{name: "indefinite array into array", data: "9f0102ff", into: new([2]int), want: [2]int{1, 2}},
+		{name: "indefinite map", data: "bf61610161629f0203ffff", into: new(map[string]any), want: map[string]any{"a": int64(1), "b": []any{int64(2), int64(3)}}},
+		{name: "map with int keys", data: "a201020304", into: new(map[int]int), want: map[int]int{1: 2, 3: 4}},
+		{name: "null into slice", data: "f6", into: &[]int{1}, want: []int(nil)},
+		{name: "undefined into int", data: "f7", into: func() *int { i := 1; return &i }(), want: 0},
+		{name: "null into pointer", data: "f6", into: new(*int), want: (*int)(nil)},
+		{name: "pointer", data: "182a", into: new(*int), want: func() *int { i := 42; return &i }()},
+		{name: "unknown tag is ignored", data: "d82018" + "2a", into: new(int), want: 42},
+		{name: "string time", data: "c074323031332d30332d32315432303a30343a30305a", into: new(time.Time), want: time.Date(2013, 3, 21, 20, 4, 0, 0, time.UTC)},
+		{name: "epoch time", data: "c11a514b67b0", into: new(time.Time), want: time.Unix(1363896240, 0).UTC()},
+		{name: "epoch time float", data: "c1fb41d452d9ec200000", into: new(time.Time), want: time.Unix(1363896240, 5e8).UTC()},
+		{name: "untagged epoch time", data: "20", into: new(time.Time), want: time.Unix(-1, 0).UTC()},
+		{
+			name: "interface",
+			data: "a6" + "616181f5" + "616220" + "61631bffffffffffffffff" + "6164f93e00" + "61654101" + "6166c101",
+			into: new(any),
+			want: map[string]any{
+				"a": []any{true}, "b": int64(-1), "c": uint64(math.MaxUint64), "d": 1.5, "e": []byte{1},
+				"f": time.Unix(1, 0).UTC(),
+			},
+		},
+		{
+			name: "struct skips unknown fields",
+			data: "a3" + "6178" + "82a16179f6c101" + "614101" + "6162617a",
+			into: &struct {
+				A int
+				B string `cbor:"b"`
+			}{},
+			want: struct {
+				A int
+				B string `cbor:"b"`
+			}{A: 1, B: "z"},
+		},
+		{
+			name: "indefinite map into struct",
+			data: "bf614101ff",
+			into: &struct{ A int }{},
+			want: struct{ A int }{A: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		if err := Unmarshal(unhex(tt.data), tt.into); err != nil {
+			t.Errorf("TestDecode(%s): Unmarshal() failed: %v", tt.name, err)
+			continue
+		}
+		if got := reflect.ValueOf(tt.into).Elem().Interface(); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("TestDecode(%s): got %#v, want %#v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		data string
+		into any
+	}{
+		{name: "not a pointer", data: "01", into: 1},
+		{name: "empty", data: "", into: new(int)},
+		{name: "trailing data", data: "0102", into: new(int)},
+		{name: "reserved additional information", data: "1c", into: new(any)},
+		{name: "indefinite integer", data: "1f", into: new(any)},
+		{name: "unexpected break", data: "ff", into: new(any)},
+		{name: "unterminated indefinite array", data: "9f01", into: new([]int)},
+		{name: "nested indefinite chunk", data: "5f5fffff", into: new([]byte)},
+		{name: "text chunk in bytes", data: "5f6161ff", into: new([]byte)},
+		{name: "short text", data: "63" + "61", into: new(string)},
+		{name: "invalid UTF-8", data: "61ff", into: new(string)},
+		{name: "huge array", data: "9affffffff", into: new([]int)},
+		{name: "overflow", data: "190100", into: new(int8)},
+		{name: "negative overflow", data: "3b8000000000000000", into: new(int64)},
+		{name: "negative into uint", data: "20", into: new(uint)},
+		{name: "float into int", data: "f90000", into: new(int)},
+		{name: "double overflows float32", data: "fb7e37e43c8800759c", into: new(float32)},
+		{name: "text into bool", data: "60", into: new(bool)},
+		{name: "simple value", data: "f0", into: new(any)},
+		{name: "array length", data: "8101", into: new([2]int)},
+		{name: "indefinite array too long", data: "9f010203ff", into: new([2]int)},
+		{name: "int map key into interface", data: "a10101", into: new(any)},
+		{name: "int map key into struct", data: "a10101", into: &struct{ A int }{}},
+		{name: "bad time string", data: "c06161", into: new(time.Time)},
+		{name: "NaN epoch time", data: "c1f97e00", into: new(time.Time)},
+		{name: "other tag for time", data: "c240", into: new(time.Time)},
+		{name: "bool into time", data: "f5", into: new(time.Time)},
+		{name: "struct field", data: "a1614160", into: &struct{ A int }{}},
+	}
+
+	for _, tt := range tests {
+		data, _ := hex.DecodeString(tt.data)
+		if err := Unmarshal(data, tt.into); err == nil {
+			t.Errorf("TestDecodeErrors(%s): Unmarshal() succeeded, want error", tt.name)
+		}
+	}
+}
+
+// nested is a recursive type for TestDecodeDepth.
+type nested []nested
+
+func TestDecodeDepth(t *testing.T) {
+	t.Parallel()
+
+	// arrays returns n nested arrays of one element around a null.
+	arrays := func(n int) []byte {
+		return append(bytes.Repeat([]byte{0x81}, n), 0xf6)
+	}
+	// indefinite returns n nested arrays of indefinite length around a null.
+	indefinite := func(n int) []byte {
+		b := append(bytes.Repeat([]byte{0x9f}, n), 0xf6)
+		return append(b, bytes.Repeat([]byte{0xff}, n)...)
+	}
+
+	tests := []struct {
+		name    string
+		data    []byte
+		into    any
+		wantErr bool
+	}{
+		{name: "max depth into interface", data: arrays(maxDepth), into: new(any)},
+		{name: "max depth into slice", data: indefinite(maxDepth), into: new(nested)},
+		{name: "too deep into interface", data: arrays(maxDepth + 1), into: new(any), wantErr: true},
+		{name: "too deep into slice", data: arrays(maxDepth + 1), into: new(nested), wantErr: true},
+		{name: "too deep indefinite", data: indefinite(maxDepth + 1), into: new(any), wantErr: true},
+		{name: "far too deep", data: arrays(5_000_000), into: new(any), wantErr: true},
+		{
+			name:    "too deep in a skipped field",
+			data:    append([]byte{0xa1, 0x61, 'B'}, arrays(maxDepth+1)...),
+			into:    &struct{ A int }{},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		err := Unmarshal(tt.data, tt.into)
+		switch {
+		case err == nil && tt.wantErr:
+			t.Errorf("TestDecodeDepth(%s): Unmarshal() succeeded, want error", tt.name)
+		case err != nil && !tt.wantErr:
+			t.Errorf("TestDecodeDepth(%s): Unmarshal() failed: %v", tt.name, err)
+		}
+	}
+}
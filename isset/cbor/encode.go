@@ -0,0 +1,351 @@
+package cbor
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/gostdlib/types/isset/internal/codec"
+)
+
+// encodeFunc appends the encoding of v to b.
+type encodeFunc func(b []byte, v reflect.Value) ([]byte, error)
+
+// encoders caches the encodeFunc for each reflect.Type.
+var encoders sync.Map
+
+var timeType = reflect.TypeFor[time.Time]()
+
+// encoderFor returns the encodeFunc for t.
+func encoderFor(t reflect.Type) encodeFunc {
+	if f, ok := encoders.Load(t); ok {
+		return f.(encodeFunc)
+	}
+
+	// Store a func that waits for the real one, so recursive types find it instead of building
+	// it again, as encoding/json does.
+	var (
+		wg sync.WaitGroup
+		f  encodeFunc
+	)
+	wg.Add(1)
+	fi, loaded := encoders.LoadOrStore(t, encodeFunc(func(b []byte, v reflect.Value) ([]byte, error) {
+		wg.Wait()
+		return f(b, v)
+	}))
+	if loaded {
+		return fi.(encodeFunc)
+	}
+	f = newEncoder(t)
+	wg.Done()
+	encoders.Store(t, f)
+	return f
+}
+
+// newEncoder builds the encodeFunc for t.
+func newEncoder(t reflect.Type) encodeFunc {
+	if t == timeType {
+		return encodeTime
+	}
+	if m, ok := codec.IssetOf(t); ok {
+		return newIssetEncoder(m)
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return encodeBool
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return encodeInt
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return encodeUint
+	case reflect.Float32, reflect.Float64:
+		return encodeFloat
+	case reflect.String:
+		return encodeString
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return encodeBytes
+		}
+		return newArrayEncoder(t)
+	case reflect.Array:
+		return newArrayEncoder(t)
+	case reflect.Map:
+		return newMapEncoder(t)
+	case reflect.Struct:
+		return newStructEncoder(t)
+	case reflect.Pointer:
+		return newPointerEncoder(t)
+	case reflect.Interface:
+		return encodeInterface
+	}
+	return func(b []byte, v reflect.Value) ([]byte, error) {
+		return b, fmt.Errorf("cbor: unsupported type %s", t)
+	}
+}
+
+func encodeBool(b []byte, v reflect.Value) ([]byte, error) {
+	if v.Bool() {
+		return append(b, simpleTrue), nil
+	}
+	return append(b, simpleFalse), nil
+}
+
+func encodeInt(b []byte, v reflect.Value) ([]byte, error) {
+	return appendInt(b, v.Int()), nil
+}
+
+func encodeUint(b []byte, v reflect.Value) ([]byte, error) {
+	return appendHead(b, majorUint, v.Uint()), nil
+}
+
+func encodeFloat(b []byte, v reflect.Value) ([]byte, error) {
+	return appendFloat(b, v.Float()), nil
+}
+
+func encodeString(b []byte, v reflect.Value) ([]byte, error) {
+	s := v.String()
+	return append(appendHead(b, majorText, uint64(len(s))), s...), nil
+}
+
+func encodeBytes(b []byte, v reflect.Value) ([]byte, error) {
+	if v.IsNil() {
+		return append(b, simpleNull), nil
+	}
+	p := v.Bytes()
+	return append(appendHead(b, majorBytes, uint64(len(p))), p...), nil
+}
+
+// encodeTime encodes a time.Time as tag 1 with an integer when it has no fractional seconds, and
+// otherwise as tag 0 with an RFC 3339 string, so that the nanoseconds are kept.
+func encodeTime(b []byte, v reflect.Value) ([]byte, error) {
+	t := v.Interface().(time.Time)
+	if t.Nanosecond() == 0 {
+		return appendInt(appendHead(b, majorTag, tagTimeEpoch), t.Unix()), nil
+	}
+	text, err := t.MarshalText()
+	if err != nil {
+		return b, fmt.Errorf("cbor: %w", err)
+	}
+	b = appendHead(appendHead(b, majorTag, tagTimeString), majorText, uint64(len(text)))
+	return append(b, text...), nil
+}
+
+func encodeInterface(b []byte, v reflect.Value) ([]byte, error) {
+	if v.IsNil() {
+		return append(b, simpleNull), nil
+	}
+	e := v.Elem()
+	return encoderFor(e.Type())(b, e)
+}
+
+func newPointerEncoder(t reflect.Type) encodeFunc {
+	elem := encoderFor(t.Elem())
+	return func(b []byte, v reflect.Value) ([]byte, error) {
+		if v.IsNil() {
+			return append(b, simpleNull), nil
+		}
+		return elem(b, v.Elem())
+	}
+}
+
+func newArrayEncoder(t reflect.Type) encodeFunc {
+	elem := encoderFor(t.Elem())
+	return func(b []byte, v reflect.Value) ([]byte, error) {
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return append(b, simpleNull), nil
+		}
+		n := v.Len()
+		b = appendHead(b, majorArray, uint64(n))
+		var err error
+		for i := 0; i < n; i++ {
+			if b, err = elem(b, v.Index(i)); err != nil {
+				return b, err
+			}
+		}
+		return b, nil
+	}
+}
+
+func newMapEncoder(t reflect.Type) encodeFunc {
+	key, elem := encoderFor(t.Key()), encoderFor(t.Elem())
+	return func(b []byte, v reflect.Value) ([]byte, error) {
+		if v.IsNil() {
+			return append(b, simpleNull), nil
+		}
+		b = appendHead(b, majorMap, uint64(v.Len()))
+		var err error
+		for it := v.MapRange(); it.Next(); {
+			if b, err = key(b, it.Key()); err != nil {
+				return b, err
+			}
+			if b, err = elem(b, it.Value()); err != nil {
+				return b, err
+			}
+		}
+		return b, nil
+	}
+}
+
+func newStructEncoder(t reflect.Type) encodeFunc {
+	fields := codec.StructFields(t, "cbor")
+	encs := make([]encodeFunc, len(fields))
+	for i, f := range fields {
+		encs[i] = encoderFor(f.Type)
+	}
+	return func(b []byte, v reflect.Value) ([]byte, error) {
+		n := 0
+		for _, f := range fields {
+			if !f.OmitEmpty || !v.Field(f.Index).IsZero() {
+				n++
+			}
+		}
+		b = appendHead(b, majorMap, uint64(n))
+		var err error
+		for i, f := range fields {
+			fv := v.Field(f.Index)
+			if f.OmitEmpty && fv.IsZero() {
+				continue
+			}
+			b = append(appendHead(b, majorText, uint64(len(f.Name))), f.Name...)
+			if b, err = encs[i](b, fv); err != nil {
+				return b, err
+			}
+		}
+		return b, nil
+	}
+}
+
+// newIssetEncoder returns an encodeFunc that encodes the value of an isset type, or null if it is unset.
+// An isset.Nullable that is absent is encoded as undefined.
+func newIssetEncoder(m codec.Isset) encodeFunc {
+	elem := newElemEncoder(m.Elem)
+	return func(b []byte, v reflect.Value) ([]byte, error) {
+		e, ok := m.Get(v)
+		switch {
+		case ok:
+			return elem(b, e)
+		case m.Nullable && !m.IsNull(v):
+			return append(b, simpleUndefined), nil
+		}
+		return append(b, simpleNull), nil
+	}
+}
+
+// newElemEncoder returns the encodeFunc for the value of an isset type. A struct without exported
+// fields, such as netip.Addr, is encoded as a byte string with its encoding.BinaryMarshaler method or
+// else as a text string with its encoding.TextMarshaler method. As null means unset, a nil slice or map
+// is encoded as an empty byte string, array or map, and a nil pointer or interface returns an error.
+func newElemEncoder(t reflect.Type) encodeFunc {
+	switch method := codec.MethodOf(t); method {
+	case codec.MethodBinary, codec.MethodText:
+		major := byte(majorBytes)
+		if method == codec.MethodText {
+			major = majorText
+		}
+		return func(b []byte, v reflect.Value) ([]byte, error) {
+			p, err := method.Marshal(v)
+			if err != nil {
+				return b, fmt.Errorf("cbor: cannot encode %s: %w", t, err)
+			}
+			return append(appendHead(b, major, uint64(len(p))), p...), nil
+		}
+	case codec.MethodMissing:
+		return func(b []byte, v reflect.Value) ([]byte, error) {
+			return b, fmt.Errorf("cbor: cannot encode %s: it has no exported fields and does not implement encoding.BinaryMarshaler or encoding.TextMarshaler", t)
+		}
+	}
+
+	enc := encoderFor(t)
+	switch t.Kind() {
+	case reflect.Slice, reflect.Map, reflect.Pointer, reflect.Interface:
+		return func(b []byte, v reflect.Value) ([]byte, error) {
+			if !v.IsNil() {
+				return enc(b, v)
+			}
+			switch {
+			case t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8:
+				return appendHead(b, majorBytes, 0), nil
+			case t.Kind() == reflect.Slice:
+				return appendHead(b, majorArray, 0), nil
+			case t.Kind() == reflect.Map:
+				return appendHead(b, majorMap, 0), nil
+			}
+			return b, fmt.Errorf("cbor: cannot encode a set %s that is nil, as null is the encoding of unset", t)
+		}
+	}
+	return enc
+}
+
+// appendHead appends the head of a value of the major type with the argument n in the shortest form.
+func appendHead(b []byte, major byte, n uint64) []byte {
+	major <<= 5
+	switch {
+	case n < info8:
+		return append(b, major|byte(n))
+	case n <= math.MaxUint8:
+		return append(b, major|info8, byte(n))
+	case n <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(b, major|info16), uint16(n))
+	case n <= math.MaxUint32:
+		return binary.BigEndian.AppendUint32(append(b, major|info32), uint32(n))
+	}
+	return binary.BigEndian.AppendUint64(append(b, major|info64), n)
+}
+
+// appendInt appends i as an unsigned or negative integer in the shortest form.
+func appendInt(b []byte, i int64) []byte {
+	if i < 0 {
+		// The argument of a negative integer is -1-i, which is the complement of i.
+		return appendHead(b, majorNegInt, uint64(^i))
+	}
+	return appendHead(b, majorUint, uint64(i))
+}
+
+// appendFloat appends f in the shortest of half, single and double precision that holds it exactly.
+func appendFloat(b []byte, f float64) []byte {
+	if math.IsNaN(f) {
+		return append(b, codeFloat16, 0x7e, 0)
+	}
+	f32 := float32(f)
+	if float64(f32) != f {
+		return binary.BigEndian.AppendUint64(append(b, codeFloat64), math.Float64bits(f))
+	}
+	if h, ok := float16Bits(f32); ok {
+		return binary.BigEndian.AppendUint16(append(b, codeFloat16), h)
+	}
+	return binary.BigEndian.AppendUint32(append(b, codeFloat32), math.Float32bits(f32))
+}
+
+// float16Bits returns the IEEE 754 half precision bits of f and if f can be held exactly in half precision.
+// f must not be NaN.
+func float16Bits(f float32) (uint16, bool) {
+	bits := math.Float32bits(f)
+	sign := uint16(bits>>16) & 0x8000
+	exp := int(bits>>23&0xff) - 127
+	mant := bits & 0x7fffff
+
+	switch {
+	case bits&0x7fffffff == 0:
+		return sign, true
+	case exp == 128:
+		// Infinity, as f is not NaN.
+		return sign | 0x7c00, true
+	case exp >= -14 && exp <= 15:
+		// A normal half, which has 10 bits of mantissa instead of 23.
+		if mant&(1<<13-1) != 0 {
+			return 0, false
+		}
+		return sign | uint16(exp+15)<<10 | uint16(mant>>13), true
+	case exp >= -24 && exp < -14:
+		// A subnormal half, which is m * 2^-24 with the implicit leading bit in m.
+		full, shift := mant|1<<23, uint(-1-exp)
+		if full&(1<<shift-1) != 0 {
+			return 0, false
+		}
+		return sign | uint16(full>>shift), true
+	}
+	return 0, false
+}
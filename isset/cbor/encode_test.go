@@ -0,0 +1,136 @@
+package cbor
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestEncode(t *testing.T) {
+	t.Parallel()
+
+	// The expected encodings are the examples in RFC 8949 appendix A where they apply.
+	tests := []struct {
+		name string
+		v    any
+		want string
+	}{
+		{name: "nil", v: nil, want: "f6"},
+		{name: "false", v: false, want: "f4"},
+		{name: "true", v: true, want: "f5"},
+		{name: "0", v: 0, want: "00"},
+		{name: "23", v: 23, want: "17"},
+		{name: "24", v: uint8(24), want: "1818"},
+		{name: "1000", v: int16(1000), want: "1903e8"},
+		{name: "1000000", v: 1000000, want: "1a000f4240"},
+		{name: "1000000000000", v: int64(1000000000000), want: "1b000000e8d4a51000"},
+		{name: "max uint64", v: uint64(math.MaxUint64), want: "1bffffffffffffffff"},
+		{name: "-1", v: -1, want: "20"},
+		{name: "-100", v: int8(-100), want: "3863"},
+		{name: "-1000", v: -1000, want: "3903e7"},
+		{name: "min int64", v: int64(math.MinInt64), want: "3b7fffffffffffffff"},
+		{name: "0.0", v: 0.0, want: "f90000"},
+		{name: "-0.0", v: math.Copysign(0, -1), want: "f98000"},
+		{name: "1.0", v: 1.0, want: "f93c00"},
+		{name: "1.1", v: 1.1, want: "fb3ff199999999999a"},
+		{name: "1.5 float32", v: float32(1.5), want: "f93e00"},
+		{name: "65504.0", v: 65504.0, want: "f97bff"},
+		{name: "100000.0", v: 100000.0, want: "fa47c35000"},
+		{name: "max float32", v: 3.4028234663852886e+38, want: "fa7f7fffff"},
+		{name: "1.0e+300", v: 1.0e+300, want: "fb7e37e43c8800759c"},
+		{name: "smallest subnormal half", v: 5.960464477539063e-8, want: "f90001"},
+		{name: "smallest normal half", v: 0.00006103515625, want: "f90400"},
+		{name: "-4.0", v: -4.0, want: "f9c400"},
+		{name: "-4.1", v: -4.1, want: "fbc010666666666666"},
+		{name: "float32 0.1", v: float32(0.1), want: "fa3dcccccd"},
+		{name: "Infinity", v: math.Inf(1), want: "f97c00"},
+		{name: "-Infinity", v: float32(math.Inf(-1)), want: "f9fc00"},
+		{name: "NaN", v: math.NaN(), want: "f97e00"},
+		{name: "empty string", v: "", want: "60"},
+		{name: "IETF", v: "IETF", want: "6449455446"},
+		{name: "u umlaut", v: "ü", want: "62c3bc"},
+		{name: "bytes", v: []byte{1, 2, 3, 4}, want: "4401020304"},
+		{name: "nil bytes", v: []byte(nil), want: "f6"},
+		{name: "empty array", v: []int{}, want: "80"},
+		{name: "array", v: [3]int{1, 2, 3}, want: "83010203"},
+		{name: "array of 25", v: make([]uint, 25), want: "981900" + string(bytes.Repeat([]byte("00"), 24))},
+		{name: "map", v: map[string]int{"a": 1}, want: "a1616101"},
+		{name: "nil map", v: map[string]int(nil), want: "f6"},
+		{name: "pointer", v: new(int), want: "00"},
+		{name: "nil pointer", v: (*int)(nil), want: "f6"},
+		{name: "interface", v: []any{"a", nil}, want: "826161f6"},
+		{name: "duration", v: time.Second, want: "1a3b9aca00"},
+		{name: "epoch time", v: time.Unix(1363896240, 0), want: "c11a514b67b0"},
+		{name: "epoch time before 1970", v: time.Unix(-1, 0), want: "c120"},
+		{
+			name: "string time",
+			v:    time.Date(2013, 3, 21, 20, 4, 0, 5e8, time.UTC),
+			want: "c076" + hex.EncodeToString([]byte("2013-03-21T20:04:00.5Z")),
+		},
+		{
+			name: "struct",
+			v: struct {
+				A       int
+				B       []int `cbor:"b"`
+				C       int   `cbor:",omitempty"`
+				D       int   `cbor:"-"`
+				private int
+			}{A: 1, B: []int{2, 3}},
+			want: "a26141016162820203",
+		},
+	}
+
+	for _, tt := range tests {
+		got, err := Marshal(tt.v)
+		if err != nil {
+			t.Errorf("TestEncode(%s): Marshal() failed: %v", tt.name, err)
+			continue
+		}
+		if want := unhex(tt.want); !bytes.Equal(got, want) {
+			t.Errorf("TestEncode(%s): Marshal() = %x, want %x", tt.name, got, want)
+		}
+	}
+}
+
+// TestEncodeFloat16 checks that every half precision value is encoded as half precision and decoded
+// back to the same value.
+func TestEncodeFloat16(t *testing.T) {
+	t.Parallel()
+
+	for h := 0; h <= math.MaxUint16; h++ {
+		f := float16Value(uint16(h))
+		if math.IsNaN(f) {
+			continue
+		}
+		want := []byte{codeFloat16, byte(h >> 8), byte(h)}
+		if got := appendFloat(nil, f); !bytes.Equal(got, want) {
+			t.Fatalf("TestEncodeFloat16(%#04x): appendFloat(%v) = %x, want %x", h, f, got, want)
+		}
+		var got float64
+		if err := Unmarshal(want, &got); err != nil || math.Float64bits(got) != math.Float64bits(f) {
+			t.Fatalf("TestEncodeFloat16(%#04x): Unmarshal() = %v, %v, want %v", h, got, err, f)
+		}
+	}
+}
+
+func TestEncodeErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		v    any
+	}{
+		{name: "chan", v: make(chan int)},
+		{name: "func in struct", v: struct{ F func() }{}},
+		{name: "complex in map", v: map[string]complex64{"a": 1}},
+		{name: "time out of range", v: time.Date(10000, 1, 1, 0, 0, 0, 1, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		if _, err := Marshal(tt.v); err == nil {
+			t.Errorf("TestEncodeErrors(%s): Marshal() succeeded, want error", tt.name)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package cbor
+
+import (
+	"bytes"
+	"net/netip"
+	"testing"
+
+	"github.com/gostdlib/types/isset"
+	"github.com/gostdlib/types/isset/internal/codectest"
+)
+
+func TestIsset(t *testing.T) {
+	t.Parallel()
+
+	codectest.Isset(t, Marshal, Unmarshal)
+}
+
+func TestIssetEncoding(t *testing.T) {
+	t.Parallel()
+
+	type small struct {
+		A isset.Int            `cbor:"a"`
+		B isset.Int            `cbor:"b,omitempty"`
+		C isset.Nullable[bool] `cbor:"c"`
+		D isset.Nullable[bool] `cbor:"d"`
+		E isset.Nullable[bool] `cbor:"e,omitempty"`
+		F isset.Float32        `cbor:"f"`
+	}
+
+	in := small{C: isset.Nullable[bool]{}.SetNull(), F: isset.Float32{}.Set(1.5)}
+	// {"a": null, "c": null, "d": undefined, "f": 1.5 as a half}
+	want := unhex("a4" + "6161f6" + "6163f6" + "6164f7" + "6166f93e00")
+	got, err := Marshal(in)
+	if err != nil || !bytes.Equal(got, want) {
+		t.Errorf("TestIssetEncoding: Marshal() = %x, %v, want %x", got, err, want)
+	}
+
+	// null and undefined make a value unset, a Nullable null and absent, and a missing field leaves it unset.
+	out := small{
+		A: isset.Int{}.Set(1),
+		B: isset.Int{}.Set(2),
+		C: isset.Nullable[bool]{}.Set(true),
+		D: isset.Nullable[bool]{}.Set(true),
+	}
+	if err := Unmarshal(unhex("a4"+"6161f6"+"6162f7"+"6163f6"+"6164f7"), &out); err != nil {
+		t.Fatalf("TestIssetEncoding: Unmarshal() failed: %v", err)
+	}
+	if out.A.IsSet() || out.B.IsSet() || !out.C.IsNull() || !out.D.IsAbsent() || !out.E.IsAbsent() || out.F.IsSet() {
+		t.Errorf("TestIssetEncoding: Unmarshal() = %+v, want a and b unset, c null, d absent and e and f untouched", out)
+	}
+}
+
+func TestIssetErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		data string
+		into any
+	}{
+		{name: "text into Int", data: "6131", into: new(isset.Int)},
+		{name: "overflow", data: "190100", into: new(isset.Uint8)},
+		{name: "bool into Time", data: "f5", into: new(isset.Time)},
+		{name: "bool into Of", data: "f5", into: new(isset.Of[struct{ X int }])},
+		{name: "negative into Uint", data: "20", into: new(isset.Uint)},
+		{name: "text into Of[netip.Addr]", data: "6131", into: new(isset.Of[netip.Addr])},
+		{name: "invalid netip.Addr", data: "4101", into: new(isset.Of[netip.Addr])},
+		{name: "Of without methods", data: "a0", into: new(isset.Of[struct{ n int }])},
+	}
+
+	for _, tt := range tests {
+		if err := Unmarshal(unhex(tt.data), tt.into); err == nil {
+			t.Errorf("TestIssetErrors(%s): Unmarshal() succeeded, want error", tt.name)
+		}
+	}
+}
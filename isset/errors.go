@@ -0,0 +1,111 @@
+package isset
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-json-experiment/json/jsontext"
+)
+
+// DecodeError is returned when a JSON value cannot be decoded into one of the types in this package.
+// Use errors.As to retrieve it, as the json package may wrap it in its own error type.
+type DecodeError struct {
+	// Type is the name of the type being decoded into, such as "isset.Int8".
+	Type string
+	// Expected is the kind of JSON value the type accepts. A JSON boolean is reported as 't'.
+	// Duration accepts both a string and a number, but reports '"'.
+	Expected jsontext.Kind
+	// Kind is the kind of the JSON value that was read.
+	Kind jsontext.Kind
+	// Pointer is the JSON Pointer (RFC 6901) to the value, such as "/server/port". It is empty when
+	// the value was decoded with UnmarshalJSON, which does not know where the value is.
+	Pointer jsontext.Pointer
+	// Offset is the input byte offset just after the value. As a JSON value that is not an object or
+	// array cannot span lines, it can be used to find the line of the value. It is -1 when the value
+	// was decoded with UnmarshalJSON.
+	Offset int64
+	// Err is the error from parsing the value, such as a number that is out of range. It is nil if
+	// the value was of the wrong kind.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *DecodeError) Error() string {
+	var b strings.Builder
+	b.WriteString("isset: cannot decode JSON ")
+	b.WriteString(e.Kind.String())
+	if e.Pointer != "" {
+		b.WriteString(" at ")
+		b.WriteString(string(e.Pointer))
+	}
+	if e.Offset >= 0 {
+		b.WriteString(" (offset ")
+		b.WriteString(strconv.FormatInt(e.Offset, 10))
+		b.WriteString(")")
+	}
+	b.WriteString(" into ")
+	b.WriteString(e.Type)
+	b.WriteString(": ")
+	if e.Err != nil {
+		b.WriteString(e.Err.Error())
+	} else {
+		b.WriteString("expected a JSON ")
+		b.WriteString(kindName(e.Expected))
+	}
+	return b.String()
+}
+
+// Unwrap returns e.Err.
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// kindName returns the name used for k in error messages.
+func kindName(k jsontext.Kind) string {
+	switch k {
+	case 't', 'f':
+		return "boolean"
+	case '{':
+		return "object"
+	case '[':
+		return "array"
+	}
+	return k.String()
+}
+
+// decodeError returns a *DecodeError for a value of kind that was just read from dec.
+func decodeError(dec *jsontext.Decoder, typ string, expected, kind jsontext.Kind, err error) *DecodeError {
+	return &DecodeError{
+		Type:     typ,
+		Expected: expected,
+		Kind:     kind,
+		Pointer:  dec.StackPointer(),
+		Offset:   dec.InputOffset(),
+		Err:      err,
+	}
+}
+
+// decodeErrorV1 returns a *DecodeError for the value data passed to UnmarshalJSON.
+func decodeErrorV1(typ string, expected jsontext.Kind, data []byte, err error) *DecodeError {
+	return &DecodeError{
+		Type:     typ,
+		Expected: expected,
+		Kind:     jsontext.Value(data).Kind(),
+		Offset:   -1,
+		Err:      err,
+	}
+}
+
+// numberTypeName returns the name of the type in this package that holds a T, such as "isset.Int8".
+func numberTypeName[T any]() string {
+	var zero T
+	name := fmt.Sprintf("%T", zero)
+	return "isset." + strings.ToUpper(name[:1]) + name[1:]
+}
+
+// at sets the location of e to the JSON Pointer ptr and the input offset.
+func (e *DecodeError) at(ptr string, offset int64) {
+	e.Pointer = jsontext.Pointer(ptr)
+	e.Offset = offset
+}
@@ -0,0 +1,139 @@
+package isset
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-json-experiment/json"
+)
+
+type decodeErrorConfig struct {
+	Server struct {
+		Port    Uint16
+		Host    String
+		Debug   Bool
+		Timeout Duration
+		Ratio   Float32
+	}
+	Retries Int8
+	Key     Bytes
+}
+
+func TestDecodeError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		src     string
+		want    DecodeError
+		wantErr bool // want an error from the underlying parse
+	}{
+		{
+			name:    "Number out of range",
+			src:     "{\n\"Server\": {\n\"Port\": 70000\n}\n}",
+			want:    DecodeError{Type: "isset.Uint16", Expected: '0', Kind: '0', Pointer: "/Server/Port", Offset: 27},
+			wantErr: true,
+		},
+		{
+			name: "Number for string",
+			src:  `{"Server": {"Host": 1}}`,
+			want: DecodeError{Type: "isset.String", Expected: '"', Kind: '0', Pointer: "/Server/Host", Offset: 21},
+		},
+		{
+			name: "String for bool",
+			src:  `{"Server": {"Debug": "yes"}}`,
+			want: DecodeError{Type: "isset.Bool", Expected: 't', Kind: '"', Pointer: "/Server/Debug", Offset: 26},
+		},
+		{
+			name:    "Bad duration",
+			src:     `{"Server": {"Timeout": "soon"}}`,
+			want:    DecodeError{Type: "isset.Duration", Expected: '"', Kind: '"', Pointer: "/Server/Timeout", Offset: 29},
+			wantErr: true,
+		},
+		{
+			name: "Bool for float",
+			src:  `{"Server": {"Ratio": true}}`,
+			want: DecodeError{Type: "isset.Float32", Expected: '0', Kind: 't', Pointer: "/Server/Ratio", Offset: 25},
+		},
+		{
+			name:    "Fraction",
+			src:     `{"Retries": 1.5}`,
+			want:    DecodeError{Type: "isset.Int8", Expected: '0', Kind: '0', Pointer: "/Retries", Offset: 15},
+			wantErr: true,
+		},
+		{
+			name:    "Bad base64",
+			src:     `{"Key": "!"}`,
+			want:    DecodeError{Type: "isset.Bytes", Expected: '"', Kind: '"', Pointer: "/Key", Offset: 11},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		var v decodeErrorConfig
+		err := json.Unmarshal([]byte(tt.src), &v)
+		var got *DecodeError
+		if !errors.As(err, &got) {
+			t.Errorf("TestDecodeError(%s): got err == %v, want a *DecodeError", tt.name, err)
+			continue
+		}
+		if got.Type != tt.want.Type || got.Expected != tt.want.Expected || got.Kind != tt.want.Kind {
+			t.Errorf("TestDecodeError(%s): got Type %s, Expected %v, Kind %v, want %s, %v, %v", tt.name, got.Type, got.Expected, got.Kind, tt.want.Type, tt.want.Expected, tt.want.Kind)
+		}
+		if got.Pointer != tt.want.Pointer {
+			t.Errorf("TestDecodeError(%s): got Pointer %q, want %q", tt.name, got.Pointer, tt.want.Pointer)
+		}
+		if got.Offset != tt.want.Offset {
+			t.Errorf("TestDecodeError(%s): got Offset %d, want %d", tt.name, got.Offset, tt.want.Offset)
+		}
+		if (got.Err != nil) != tt.wantErr {
+			t.Errorf("TestDecodeError(%s): got Err == %v, want Err != nil == %v", tt.name, got.Err, tt.wantErr)
+		}
+	}
+}
+
+func TestDecodeErrorV1(t *testing.T) {
+	t.Parallel()
+
+	var v Int8
+	err := v.UnmarshalJSON([]byte(`"1"`))
+	var got *DecodeError
+	if !errors.As(err, &got) {
+		t.Fatalf("TestDecodeErrorV1: got err == %v, want a *DecodeError", err)
+	}
+	if got.Type != "isset.Int8" || got.Expected != '0' || got.Kind != '"' || got.Pointer != "" || got.Offset != -1 {
+		t.Errorf("TestDecodeErrorV1: got %+v, want Type isset.Int8, Expected '0', Kind '\"', no Pointer and Offset -1", got)
+	}
+}
+
+func TestDecodeErrorMessage(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		err  *DecodeError
+		want string
+	}{
+		{
+			name: "Wrong kind",
+			err:  &DecodeError{Type: "isset.Bool", Expected: 't', Kind: '"', Pointer: "/a/b", Offset: 12},
+			want: "isset: cannot decode JSON string at /a/b (offset 12) into isset.Bool: expected a JSON boolean",
+		},
+		{
+			name: "Parse error",
+			err:  &DecodeError{Type: "isset.Int8", Expected: '0', Kind: '0', Pointer: "/a", Offset: 3, Err: errors.New("value 300 out of range")},
+			want: "isset: cannot decode JSON number at /a (offset 3) into isset.Int8: value 300 out of range",
+		},
+		{
+			name: "No location",
+			err:  &DecodeError{Type: "isset.String", Expected: '"', Kind: '0', Offset: -1},
+			want: "isset: cannot decode JSON number into isset.String: expected a JSON string",
+		},
+	}
+
+	for _, tt := range tests {
+		if got := tt.err.Error(); got != tt.want {
+			t.Errorf("TestDecodeErrorMessage(%s): got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,214 @@
+package isset
+
+import (
+	"encoding"
+	"flag"
+)
+
+// textValue is implemented by pointers to the types in this package.
+type textValue interface {
+	encoding.TextMarshaler
+	encoding.TextUnmarshaler
+}
+
+// FlagValue adapts a pointer to one of the types in this package to the flag.Value interface. The value
+// is only changed (and marked as set) when the flag is passed on the command line, which allows flags to
+// be layered over values read from a configuration file. Values are parsed with UnmarshalText, so an
+// empty value such as -port= makes the value unset, except for String and Bytes.
+//
+// FlagValue also has the Type method of the pflag.Value interface, so it can be used with
+// github.com/spf13/pflag without this package importing it.
+type FlagValue struct {
+	p   textValue
+	typ string
+}
+
+// NewFlagValue returns a FlagValue that stores flag values in p. p is usually a pointer to one of the
+// types in this package, such as *Int, but any type with text methods is accepted. For a *Of[T] or
+// *Nullable[T], T must implement the text methods.
+func NewFlagValue(p interface {
+	encoding.TextMarshaler
+	encoding.TextUnmarshaler
+}) *FlagValue {
+	return &FlagValue{p: p, typ: flagType(p)}
+}
+
+// String implements the flag.Value interface. An unset value is the empty string.
+func (f *FlagValue) String() string {
+	// The flag package calls String on a zero FlagValue to detect default values.
+	if f == nil || f.p == nil {
+		return ""
+	}
+	b, err := f.p.MarshalText()
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
+
+// Set implements the flag.Value interface.
+func (f *FlagValue) Set(s string) error {
+	return f.p.UnmarshalText([]byte(s))
+}
+
+// Get implements the flag.Getter interface. It returns the pointer passed to NewFlagValue.
+func (f *FlagValue) Get() any {
+	return f.p
+}
+
+// IsBoolFlag reports if the flag can be passed without a value, as in -verbose instead of -verbose=true.
+// This is true for *Bool.
+func (f *FlagValue) IsBoolFlag() bool {
+	_, ok := f.p.(*Bool)
+	return ok
+}
+
+// Type implements the Type method of the pflag.Value interface. It returns the same type names pflag
+// uses for its own flags, such as "int" or "duration".
+func (f *FlagValue) Type() string {
+	return f.typ
+}
+
+// flagType returns the pflag type name for p.
+func flagType(p textValue) string {
+	switch p.(type) {
+	case *Bool:
+		return "bool"
+	case *String:
+		return "string"
+	case *Int:
+		return "int"
+	case *Int8:
+		return "int8"
+	case *Int16:
+		return "int16"
+	case *Int32:
+		return "int32"
+	case *Int64:
+		return "int64"
+	case *Uint:
+		return "uint"
+	case *Uint8:
+		return "uint8"
+	case *Uint16:
+		return "uint16"
+	case *Uint32:
+		return "uint32"
+	case *Uint64:
+		return "uint64"
+	case *Float32:
+		return "float32"
+	case *Float64:
+		return "float64"
+	case *Duration:
+		return "duration"
+	case *Time:
+		return "time"
+	case *Bytes:
+		return "bytesBase64"
+	}
+	return "value"
+}
+
+// BoolVar defines a bool flag with the specified name and usage string in fs. The argument p points to
+// a Bool variable that is set only if the flag is passed. If fs is nil, flag.CommandLine is used.
+func BoolVar(fs *flag.FlagSet, p *Bool, name, usage string) {
+	flagVar(fs, p, name, usage)
+}
+
+// StringVar defines a string flag with the specified name and usage string in fs. The argument p points
+// to a String variable that is set only if the flag is passed. If fs is nil, flag.CommandLine is used.
+func StringVar(fs *flag.FlagSet, p *String, name, usage string) {
+	flagVar(fs, p, name, usage)
+}
+
+// IntVar defines an int flag with the specified name and usage string in fs. The argument p points to
+// an Int variable that is set only if the flag is passed. If fs is nil, flag.CommandLine is used.
+func IntVar(fs *flag.FlagSet, p *Int, name, usage string) {
+	flagVar(fs, p, name, usage)
+}
+
+// Int8Var is like IntVar for an Int8.
+func Int8Var(fs *flag.FlagSet, p *Int8, name, usage string) {
+	flagVar(fs, p, name, usage)
+}
+
+// Int16Var is like IntVar for an Int16.
+func Int16Var(fs *flag.FlagSet, p *Int16, name, usage string) {
+	flagVar(fs, p, name, usage)
+}
+
+// Int32Var is like IntVar for an Int32.
+func Int32Var(fs *flag.FlagSet, p *Int32, name, usage string) {
+	flagVar(fs, p, name, usage)
+}
+
+// Int64Var is like IntVar for an Int64.
+func Int64Var(fs *flag.FlagSet, p *Int64, name, usage string) {
+	flagVar(fs, p, name, usage)
+}
+
+// UintVar defines a uint flag with the specified name and usage string in fs. The argument p points to
+// a Uint variable that is set only if the flag is passed. If fs is nil, flag.CommandLine is used.
+func UintVar(fs *flag.FlagSet, p *Uint, name, usage string) {
+	flagVar(fs, p, name, usage)
+}
+
+// Uint8Var is like UintVar for a Uint8.
+func Uint8Var(fs *flag.FlagSet, p *Uint8, name, usage string) {
+	flagVar(fs, p, name, usage)
+}
+
+// Uint16Var is like UintVar for a Uint16.
+func Uint16Var(fs *flag.FlagSet, p *Uint16, name, usage string) {
+	flagVar(fs, p, name, usage)
+}
+
+// Uint32Var is like UintVar for a Uint32.
+func Uint32Var(fs *flag.FlagSet, p *Uint32, name, usage string) {
+	flagVar(fs, p, name, usage)
+}
+
+// Uint64Var is like UintVar for a Uint64.
+func Uint64Var(fs *flag.FlagSet, p *Uint64, name, usage string) {
+	flagVar(fs, p, name, usage)
+}
+
+// Float32Var defines a float32 flag with the specified name and usage string in fs. The argument p points
+// to a Float32 variable that is set only if the flag is passed. If fs is nil, flag.CommandLine is used.
+func Float32Var(fs *flag.FlagSet, p *Float32, name, usage string) {
+	flagVar(fs, p, name, usage)
+}
+
+// Float64Var is like Float32Var for a Float64.
+func Float64Var(fs *flag.FlagSet, p *Float64, name, usage string) {
+	flagVar(fs, p, name, usage)
+}
+
+// DurationVar defines a time.Duration flag with the specified name and usage string in fs. The argument
+// p points to a Duration variable that is set only if the flag is passed. The flag accepts Go duration
+// strings such as "1m30s". If fs is nil, flag.CommandLine is used.
+func DurationVar(fs *flag.FlagSet, p *Duration, name, usage string) {
+	flagVar(fs, p, name, usage)
+}
+
+// TimeVar defines a time.Time flag with the specified name and usage string in fs. The argument p points
+// to a Time variable that is set only if the flag is passed. The flag accepts RFC 3339 timestamps.
+// If fs is nil, flag.CommandLine is used.
+func TimeVar(fs *flag.FlagSet, p *Time, name, usage string) {
+	flagVar(fs, p, name, usage)
+}
+
+// BytesVar defines a []byte flag with the specified name and usage string in fs. The argument p points
+// to a Bytes variable that is set only if the flag is passed. The flag accepts standard base64.
+// If fs is nil, flag.CommandLine is used.
+func BytesVar(fs *flag.FlagSet, p *Bytes, name, usage string) {
+	flagVar(fs, p, name, usage)
+}
+
+func flagVar(fs *flag.FlagSet, p textValue, name, usage string) {
+	if fs == nil {
+		fs = flag.CommandLine
+	}
+	fs.Var(NewFlagValue(p), name, usage)
+}
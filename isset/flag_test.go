@@ -0,0 +1,130 @@
+package isset
+
+import (
+	"flag"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFlags(t *testing.T) {
+	t.Parallel()
+
+	type flags struct {
+		verbose Bool
+		name    String
+		workers Int
+		port    Uint16
+		ratio   Float64
+		timeout Duration
+	}
+
+	tests := []struct {
+		name    string
+		args    []string
+		initial flags
+		want    flags
+		wantErr bool
+	}{
+		{
+			name: "No flags leaves values unset",
+			args: nil,
+			want: flags{},
+		},
+		{
+			name: "All flags",
+			args: []string{"-verbose", "-name=", "-workers", "0", "-port=0x1F90", "-ratio=0.5", "-timeout=1m"},
+			want: flags{
+				verbose: Bool{}.Set(true),
+				name:    String{}.Set(""),
+				workers: Int{}.Set(0),
+				port:    Uint16{}.Set(8080),
+				ratio:   Float64{}.Set(0.5),
+				timeout: Duration{}.Set(time.Minute),
+			},
+		},
+		{
+			name:    "Flags override initial values",
+			args:    []string{"-verbose=false", "-workers=4"},
+			initial: flags{verbose: Bool{}.Set(true), name: String{}.Set("from-file")},
+			want: flags{
+				verbose: Bool{}.Set(false),
+				name:    String{}.Set("from-file"),
+				workers: Int{}.Set(4),
+			},
+		},
+		{
+			name:    "Bad value",
+			args:    []string{"-port=70000"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		got := tt.initial
+		fs := flag.NewFlagSet(tt.name, flag.ContinueOnError)
+		fs.SetOutput(io.Discard)
+		BoolVar(fs, &got.verbose, "verbose", "")
+		StringVar(fs, &got.name, "name", "")
+		IntVar(fs, &got.workers, "workers", "")
+		Uint16Var(fs, &got.port, "port", "")
+		Float64Var(fs, &got.ratio, "ratio", "")
+		DurationVar(fs, &got.timeout, "timeout", "")
+
+		err := fs.Parse(tt.args)
+		switch {
+		case err == nil && tt.wantErr:
+			t.Errorf("TestFlags(%s): got err == nil, want err != nil", tt.name)
+			continue
+		case err != nil && !tt.wantErr:
+			t.Errorf("TestFlags(%s): got err == %s, want err == nil", tt.name, err)
+			continue
+		case err != nil:
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("TestFlags(%s): got %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestFlagValue(t *testing.T) {
+	t.Parallel()
+
+	var d Duration
+	v := NewFlagValue(&d)
+	if got := v.Type(); got != "duration" {
+		t.Errorf("TestFlagValue: Type() = %q, want %q", got, "duration")
+	}
+	if v.IsBoolFlag() {
+		t.Errorf("TestFlagValue: IsBoolFlag() = true for *Duration, want false")
+	}
+	if got := v.String(); got != "" {
+		t.Errorf("TestFlagValue: String() of unset = %q, want empty", got)
+	}
+	if err := v.Set("2s"); err != nil {
+		t.Fatalf("TestFlagValue: Set() failed: %v", err)
+	}
+	if got := v.String(); got != "2s" {
+		t.Errorf("TestFlagValue: String() = %q, want %q", got, "2s")
+	}
+	if got := v.Get().(*Duration); got != &d {
+		t.Errorf("TestFlagValue: Get() = %p, want %p", got, &d)
+	}
+
+	var b Bool
+	if !NewFlagValue(&b).IsBoolFlag() {
+		t.Errorf("TestFlagValue: IsBoolFlag() = false for *Bool, want true")
+	}
+
+	// The flag package calls String on a zero FlagValue when printing defaults.
+	var sb strings.Builder
+	fs := flag.NewFlagSet("defaults", flag.ContinueOnError)
+	fs.SetOutput(&sb)
+	IntVar(fs, new(Int), "workers", "number of workers")
+	fs.PrintDefaults()
+	if !strings.Contains(sb.String(), "number of workers") {
+		t.Errorf("TestFlagValue: PrintDefaults() = %q, want usage in output", sb.String())
+	}
+}
@@ -2,6 +2,8 @@ package isset
 
 import (
 	"fmt"
+	"math"
+	"strconv"
 
 	"github.com/go-json-experiment/json"
 	"github.com/go-json-experiment/json/jsontext"
@@ -28,6 +30,11 @@ func (i floatType[T]) IsSet() bool {
 	return i.isSet
 }
 
+// IsZero reports if the value is unset.
+func (i floatType[T]) IsZero() bool {
+	return !i.isSet
+}
+
 // Set sets the value and marks it as set.
 func (i floatType[T]) Set(val T) floatType[T] {
 	i.v = val
@@ -45,19 +52,72 @@ func (i floatType[T]) Unset() floatType[T] {
 
 // MarshalJSON implements the json.Marshaler interface.
 func (i floatType[T]) MarshalJSON() ([]byte, error) {
-	if !i.isSet {
-		return []byte{}, nil
+	return i.AppendJSON(make([]byte, 0, 34))
+}
+
+// AppendJSON appends the JSON encoding returned by MarshalJSON to dst. It lets encoders write the
+// value into their own buffer without allocating.
+func (i floatType[T]) AppendJSON(dst []byte) ([]byte, error) {
+	return i.appendJSON(dst, getDefaults())
+}
+
+// appendJSON is AppendJSON with the options in o.
+func (i floatType[T]) appendJSON(dst []byte, o *options) ([]byte, error) {
+	if o.writeNull(i.isSet) {
+		return append(dst, "null"...), nil
+	}
+	if s, ok := nonFiniteName(float64(i.v)); ok {
+		if !o.nonFinite {
+			return dst, fmt.Errorf("isset: cannot encode %s as JSON without WithNonFinite", s)
+		}
+		return strconv.AppendQuote(dst, s), nil
 	}
-	return json.Marshal(i.v)
+	if o.stringify {
+		dst = appendFloat(append(dst, '"'), float64(i.v), bitSize[T]())
+		return append(dst, '"'), nil
+	}
+	return appendFloat(dst, float64(i.v), bitSize[T]()), nil
 }
 
 // MarshalJSONV2 implements the json.MarshalerV2 interface.
 func (i floatType[T]) MarshalJSONV2(enc *jsontext.Encoder, opts json.Options) error {
-	return enc.WriteToken(jsontext.Float(float64(i.v)))
+	return i.marshalJSONV2(enc, opts, getDefaults())
+}
+
+func (i floatType[T]) marshalJSONV2(enc *jsontext.Encoder, opts json.Options, o *options) error {
+	if o.writeNull(i.isSet) {
+		return enc.WriteToken(jsontext.Null)
+	}
+	format, err := formatOf(opts, enc.StackDepth())
+	if err != nil {
+		return err
+	}
+	if format != "" && format != "nonfinite" {
+		return invalidFormatError(numberTypeName[T](), format)
+	}
+	if s, ok := nonFiniteName(float64(i.v)); ok {
+		// jsontext.Float would write the name as a string, which could not be decoded without the option.
+		if !o.nonFinite && format != "nonfinite" {
+			return fmt.Errorf("isset: cannot encode %s as JSON without WithNonFinite or the format:nonfinite tag option", s)
+		}
+		return enc.WriteToken(jsontext.String(s))
+	}
+	// jsontext.Float formats with 64 bits, which gives a Float32 more digits than it has.
+	var buf [32]byte
+	b := appendFloat(buf[:0], float64(i.v), bitSize[T]())
+	if o.stringify || stringifyNumbers(opts) {
+		return enc.WriteToken(jsontext.String(string(b)))
+	}
+	return enc.WriteValue(b)
 }
 
 // UnmarshalJSON implements the json.Unmarshaler interface.
 func (i *floatType[T]) UnmarshalJSON(data []byte) error {
+	return i.unmarshalJSON(data, getDefaults())
+}
+
+// unmarshalJSON is UnmarshalJSON with the options in o.
+func (i *floatType[T]) unmarshalJSON(data []byte, o *options) error {
 	if bytesToStr(data) == "null" {
 		var zero T
 		i.isSet = false
@@ -65,17 +125,57 @@ func (i *floatType[T]) UnmarshalJSON(data []byte) error {
 		return nil
 	}
 
-	i.isSet = true
-	var t T
-	if err := json.Unmarshal(data, &t); err != nil {
-		return err
+	switch jsontext.Value(data).Kind() {
+	case '0':
+		t, err := parseFloat[T](bytesToStr(data))
+		if err != nil {
+			return decodeErrorV1(numberTypeName[T](), '0', data, err)
+		}
+		i.v = t
+		i.isSet = true
+		return nil
+	case '"':
+		if o.nonFinite || o.stringify || o.lenient {
+			err := unquoteV1(data, func(s string) error {
+				return i.setString(s, o.nonFinite, o.stringify, o.lenient)
+			})
+			if err != nil {
+				return decodeErrorV1(numberTypeName[T](), '0', data, err)
+			}
+			return nil
+		}
 	}
-	i.v = t
-	return nil
+	return decodeErrorV1(numberTypeName[T](), '0', data, nil)
 }
 
 // UnmarshalJSONV2 implements the json.UnmarshalerV2 interface.
 func (v *floatType[T]) UnmarshalJSONV2(dec *jsontext.Decoder, opts json.Options) error {
+	return v.unmarshalJSONV2(dec, opts, getDefaults())
+}
+
+func (v *floatType[T]) unmarshalJSONV2(dec *jsontext.Decoder, opts json.Options, o *options) error {
+	format, err := formatOf(opts, dec.StackDepth())
+	if err != nil {
+		return err
+	}
+	if format != "" && format != "nonfinite" {
+		return invalidFormatError(numberTypeName[T](), format)
+	}
+
+	// Numbers are parsed from the raw value, as Token.String allocates a string for them.
+	if dec.PeekKind() == '0' {
+		val, err := dec.ReadValue()
+		if err != nil {
+			return err
+		}
+		n, err := parseFloat[T](bytesToStr(val))
+		if err != nil {
+			return decodeError(dec, numberTypeName[T](), '0', '0', err)
+		}
+		*v = v.Set(n)
+		return nil
+	}
+
 	t, err := dec.ReadToken()
 	if err != nil {
 		return err
@@ -86,10 +186,96 @@ func (v *floatType[T]) UnmarshalJSONV2(dec *jsontext.Decoder, opts json.Options)
 		v.isSet = false
 		v.v = 0
 		return nil
-	case '0':
-		v.isSet = true
-		v.v = T(t.Float())
+	case '"':
+		nonFinite := o.nonFinite || format == "nonfinite"
+		stringify := o.stringify || stringifyNumbers(opts)
+		if nonFinite || stringify || o.lenient {
+			if err := v.setString(t.String(), nonFinite, stringify, o.lenient); err != nil {
+				return decodeError(dec, numberTypeName[T](), '0', '"', err)
+			}
+			return nil
+		}
+	}
+	return decodeError(dec, numberTypeName[T](), '0', t.Kind(), nil)
+}
+
+// setString sets v from the JSON string s. If nonFinite is set, s may be "NaN", "Infinity" or
+// "-Infinity". If stringify is set, s may be a JSON number. If lenient is set, s may be any value
+// that setLenient accepts.
+func (v *floatType[T]) setString(s string, nonFinite, stringify, lenient bool) error {
+	if nonFinite {
+		if f, ok := parseNonFinite(s); ok {
+			*v = v.Set(T(f))
+			return nil
+		}
+	}
+	switch {
+	case lenient:
+		return v.setLenient(s, nonFinite)
+	case stringify && isNumber(s):
+		f, err := parseFloat[T](s)
+		if err != nil {
+			return err
+		}
+		*v = v.Set(f)
+		return nil
+	}
+	return fmt.Errorf("invalid number %q", s)
+}
+
+// nonFiniteName returns the JSON string used for f if it is NaN or an infinity, matching the
+// `format:nonfinite` struct tag option of the v2 json package.
+func nonFiniteName(f float64) (string, bool) {
+	switch {
+	case math.IsNaN(f):
+		return "NaN", true
+	case math.IsInf(f, 1):
+		return "Infinity", true
+	case math.IsInf(f, -1):
+		return "-Infinity", true
+	}
+	return "", false
+}
+
+// parseNonFinite returns the value for a string returned by nonFiniteName.
+func parseNonFinite(s string) (float64, bool) {
+	switch s {
+	case "NaN":
+		return math.NaN(), true
+	case "Infinity":
+		return math.Inf(1), true
+	case "-Infinity":
+		return math.Inf(-1), true
+	}
+	return 0, false
+}
+
+// MarshalText implements the encoding.TextMarshaler interface. An unset value marshals to empty text.
+func (i floatType[T]) MarshalText() ([]byte, error) {
+	return i.AppendText(nil)
+}
+
+// AppendText implements the encoding.TextAppender interface.
+func (i floatType[T]) AppendText(b []byte) ([]byte, error) {
+	if !i.isSet {
+		return b, nil
+	}
+	return strconv.AppendFloat(b, float64(i.v), 'g', -1, bitSize[T]()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface. It accepts the values
+// strconv.ParseFloat accepts and marks the value as set on success. Empty text, which MarshalText
+// writes for an unset value, makes the value unset.
+func (i *floatType[T]) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*i = i.Unset()
 		return nil
 	}
-	return fmt.Errorf("expected a JSON number, got %T", t.Kind())
+	f, err := strconv.ParseFloat(bytesToStr(text), bitSize[T]())
+	if err != nil {
+		return err
+	}
+	i.v = T(f)
+	i.isSet = true
+	return nil
 }
@@ -2,6 +2,7 @@ package isset
 
 import (
 	"bytes"
+	"math"
 	"testing"
 
 	"github.com/go-json-experiment/json"
@@ -91,7 +92,7 @@ func TestFloat64Marshalling(t *testing.T) {
 			jsonInput:   "",
 			wantFloat64: 0,
 			wantIsSet:   false,
-			wantOutput:  "",
+			wantOutput:  "null",
 		},
 		{
 			name:        "Unmarshal set Float64",
@@ -152,3 +153,167 @@ func TestFloat64Marshalling(t *testing.T) {
 		}
 	}
 }
+
+func TestFloat32Text(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		text     string
+		want     Float32
+		wantErr  bool
+		wantText string
+	}{
+		{name: "decimal", text: "1.5", want: Float32{}.Set(1.5), wantText: "1.5"},
+		{name: "exponent", text: "-2e3", want: Float32{}.Set(-2000), wantText: "-2000"},
+		{name: "shortest float32", text: "0.1", want: Float32{}.Set(0.1), wantText: "0.1"},
+		{name: "out of range", text: "1e39", wantErr: true},
+		{name: "garbage", text: "one", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		var v Float32
+		err := v.UnmarshalText([]byte(tt.text))
+		switch {
+		case err == nil && tt.wantErr:
+			t.Errorf("TestFloat32Text(%s): got err == nil, want err != nil", tt.name)
+			continue
+		case err != nil && !tt.wantErr:
+			t.Errorf("TestFloat32Text(%s): got err == %s, want err == nil", tt.name, err)
+			continue
+		case err != nil:
+			if v.IsSet() {
+				t.Errorf("TestFloat32Text(%s): IsSet() = true after error, want false", tt.name)
+			}
+			continue
+		}
+		if v != tt.want {
+			t.Errorf("TestFloat32Text(%s): got %v(set %v), want %v(set %v)", tt.name, v.V(), v.IsSet(), tt.want.V(), tt.want.IsSet())
+		}
+
+		text, err := v.MarshalText()
+		if err != nil {
+			t.Fatalf("TestFloat32Text(%s): MarshalText() failed: %v", tt.name, err)
+		}
+		if string(text) != tt.wantText {
+			t.Errorf("TestFloat32Text(%s): MarshalText() = %q, want %q", tt.name, text, tt.wantText)
+		}
+	}
+
+	text, err := Float32{}.MarshalText()
+	if err != nil || len(text) != 0 {
+		t.Errorf("TestFloat32Text(unset): MarshalText() = %q, %v, want empty text", text, err)
+	}
+	b, err := Float32{}.Set(0.25).AppendText([]byte("x="))
+	if err != nil || string(b) != "x=0.25" {
+		t.Errorf("TestFloat32Text(append): AppendText() = %q, %v, want %q", b, err, "x=0.25")
+	}
+}
+
+type nonFiniteConfig struct {
+	A Float64
+	B Float32 `json:",format:nonfinite"`
+}
+
+func TestFloatNonFinite(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		v       Float64
+		want    string
+		opts    json.Options
+		wantErr bool
+	}{
+		{name: "NaN without option", v: Float64{}.Set(math.NaN()), wantErr: true},
+		{name: "NaN", v: Float64{}.Set(math.NaN()), opts: JSONOptions(WithNonFinite(true)), want: `"NaN"`},
+		{name: "+Inf", v: Float64{}.Set(math.Inf(1)), opts: JSONOptions(WithNonFinite(true)), want: `"Infinity"`},
+		{name: "-Inf", v: Float64{}.Set(math.Inf(-1)), opts: JSONOptions(WithNonFinite(true)), want: `"-Infinity"`},
+		{name: "Finite", v: Float64{}.Set(1.5), opts: JSONOptions(WithNonFinite(true)), want: `1.5`},
+	}
+
+	for _, tt := range tests {
+		b, err := json.Marshal(tt.v, tt.opts)
+		switch {
+		case err == nil && tt.wantErr:
+			t.Errorf("TestFloatNonFinite(%s): got err == nil, want err != nil", tt.name)
+			continue
+		case err != nil && !tt.wantErr:
+			t.Errorf("TestFloatNonFinite(%s): got err == %s, want err == nil", tt.name, err)
+			continue
+		case err != nil:
+			continue
+		}
+		if string(b) != tt.want {
+			t.Errorf("TestFloatNonFinite(%s): Marshal() = %s, want %s", tt.name, b, tt.want)
+		}
+
+		var got Float64
+		if err := json.Unmarshal(b, &got, tt.opts); err != nil {
+			t.Fatalf("TestFloatNonFinite(%s): Unmarshal() failed: %v", tt.name, err)
+		}
+		if !got.IsSet() || !sameFloat(got.V(), tt.v.V()) {
+			t.Errorf("TestFloatNonFinite(%s): Unmarshal() = %v(set %v), want %v", tt.name, got.V(), got.IsSet(), tt.v.V())
+		}
+	}
+
+	// Without the option, the strings are rejected.
+	var v Float64
+	if err := json.Unmarshal([]byte(`"NaN"`), &v); err == nil {
+		t.Errorf("TestFloatNonFinite: Unmarshal(\"NaN\") without option succeeded, want error")
+	}
+
+	// The format:nonfinite struct tag option applies only to the tagged field.
+	c := nonFiniteConfig{A: Float64{}.Set(1), B: Float32{}.Set(float32(math.Inf(-1)))}
+	b, err := json.Marshal(c)
+	if err != nil {
+		t.Fatalf("TestFloatNonFinite(format): Marshal() failed: %v", err)
+	}
+	want := `{"A":1,"B":"-Infinity"}`
+	if string(b) != want {
+		t.Errorf("TestFloatNonFinite(format): Marshal() = %s, want %s", b, want)
+	}
+	var got nonFiniteConfig
+	if err := json.Unmarshal(b, &got); err != nil || !math.IsInf(float64(got.B.V()), -1) {
+		t.Errorf("TestFloatNonFinite(format): Unmarshal() = %v, got %v, want -Inf", err, got.B.V())
+	}
+	if err := json.Unmarshal([]byte(`{"A":"NaN"}`), &got); err == nil {
+		t.Errorf("TestFloatNonFinite(format): Unmarshal() of untagged NaN succeeded, want error")
+	}
+}
+
+// TestFloatNonFiniteDefaults is not parallel, as it changes the package level options.
+func TestFloatNonFiniteDefaults(t *testing.T) {
+	defer SetDefaults()
+
+	nan := Float64{}.Set(math.NaN())
+	if _, err := nan.MarshalJSON(); err == nil {
+		t.Errorf("TestFloatNonFiniteDefaults: MarshalJSON(NaN) without option succeeded, want error")
+	}
+
+	SetDefaults(WithNonFinite(true))
+
+	for _, f := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} {
+		v := Float64{}.Set(f)
+		b, err := v.MarshalJSON()
+		if err != nil {
+			t.Fatalf("TestFloatNonFiniteDefaults: MarshalJSON(%v) failed: %v", f, err)
+		}
+		var got Float32
+		if err := got.UnmarshalJSON(b); err != nil {
+			t.Fatalf("TestFloatNonFiniteDefaults: UnmarshalJSON(%s) failed: %v", b, err)
+		}
+		if !sameFloat(float64(got.V()), f) {
+			t.Errorf("TestFloatNonFiniteDefaults: UnmarshalJSON(%s) = %v, want %v", b, got.V(), f)
+		}
+	}
+	var got Float64
+	if err := got.UnmarshalJSON([]byte(`"1.5"`)); err == nil {
+		t.Errorf("TestFloatNonFiniteDefaults: UnmarshalJSON(\"1.5\") succeeded without WithLenient, want error")
+	}
+}
+
+// sameFloat reports if a and b are equal, treating NaNs as equal.
+func sameFloat(a, b float64) bool {
+	return a == b || (math.IsNaN(a) && math.IsNaN(b))
+}
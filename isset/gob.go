@@ -0,0 +1,56 @@
+package isset
+
+// This file implements the gob.GobEncoder and gob.GobDecoder interfaces, which encoding/gob needs as the
+// types only have unexported fields. The encoding is the binary encoding of MarshalBinary, which keeps the
+// value and if it is set. encoding/gob uses MarshalBinary for the other types, as they do not implement
+// the gob interfaces.
+
+// GobEncode implements the gob.GobEncoder interface.
+func (i Bool) GobEncode() ([]byte, error) {
+	return i.MarshalBinary()
+}
+
+// GobDecode implements the gob.GobDecoder interface.
+func (i *Bool) GobDecode(data []byte) error {
+	return i.UnmarshalBinary(data)
+}
+
+// GobEncode implements the gob.GobEncoder interface.
+func (i String) GobEncode() ([]byte, error) {
+	return i.MarshalBinary()
+}
+
+// GobDecode implements the gob.GobDecoder interface.
+func (i *String) GobDecode(data []byte) error {
+	return i.UnmarshalBinary(data)
+}
+
+// GobEncode implements the gob.GobEncoder interface.
+func (i intType[T]) GobEncode() ([]byte, error) {
+	return i.MarshalBinary()
+}
+
+// GobDecode implements the gob.GobDecoder interface.
+func (i *intType[T]) GobDecode(data []byte) error {
+	return i.UnmarshalBinary(data)
+}
+
+// GobEncode implements the gob.GobEncoder interface.
+func (i uintType[T]) GobEncode() ([]byte, error) {
+	return i.MarshalBinary()
+}
+
+// GobDecode implements the gob.GobDecoder interface.
+func (i *uintType[T]) GobDecode(data []byte) error {
+	return i.UnmarshalBinary(data)
+}
+
+// GobEncode implements the gob.GobEncoder interface.
+func (i floatType[T]) GobEncode() ([]byte, error) {
+	return i.MarshalBinary()
+}
+
+// GobDecode implements the gob.GobDecoder interface.
+func (i *floatType[T]) GobDecode(data []byte) error {
+	return i.UnmarshalBinary(data)
+}
@@ -0,0 +1,138 @@
+package isset
+
+import (
+	"bytes"
+	"encoding/gob"
+	"math"
+	"testing"
+)
+
+type gobConfig struct {
+	Name    String
+	Empty   String
+	Debug   Bool
+	Verbose Bool
+	Port    Uint16
+	Count   Uint
+	Offset  Int8
+	Limit   Int
+	Big     Int64
+	Ratio   Float32
+	Scale   Float64
+	Unset   Int32
+}
+
+func TestGob(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		in   gobConfig
+	}{
+		{name: "Unset"},
+		{
+			name: "Zero values",
+			in: gobConfig{
+				Name:   String{}.Set(""),
+				Debug:  Bool{}.Set(false),
+				Port:   Uint16{}.Set(0),
+				Offset: Int8{}.Set(0),
+				Ratio:  Float32{}.Set(0),
+			},
+		},
+		{
+			name: "Values",
+			in: gobConfig{
+				Name:    String{}.Set("api"),
+				Empty:   String{}.Set(""),
+				Debug:   Bool{}.Set(true),
+				Verbose: Bool{}.Set(false),
+				Port:    Uint16{}.Set(math.MaxUint16),
+				Count:   Uint{}.Set(42),
+				Offset:  Int8{}.Set(math.MinInt8),
+				Limit:   Int{}.Set(-1),
+				Big:     Int64{}.Set(math.MaxInt64),
+				Ratio:   Float32{}.Set(0.1),
+				Scale:   Float64{}.Set(math.Inf(-1)),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		if err := gob.NewEncoder(&buf).Encode(tt.in); err != nil {
+			t.Errorf("TestGob(%s): Encode() failed: %v", tt.name, err)
+			continue
+		}
+		var got gobConfig
+		if err := gob.NewDecoder(&buf).Decode(&got); err != nil {
+			t.Errorf("TestGob(%s): Decode() failed: %v", tt.name, err)
+			continue
+		}
+		if got != tt.in {
+			t.Errorf("TestGob(%s): got %+v, want %+v", tt.name, got, tt.in)
+		}
+	}
+}
+
+func TestGobEncode(t *testing.T) {
+	t.Parallel()
+
+	type gobber interface {
+		GobEncode() ([]byte, error)
+	}
+
+	tests := []struct {
+		name string
+		v    gobber
+		want []byte
+	}{
+		{name: "Bool unset", v: Bool{}, want: []byte{0}},
+		{name: "Bool", v: Bool{}.Set(true), want: []byte{1, 1}},
+		{name: "String", v: String{}.Set("hi"), want: []byte{1, 2, 'h', 'i'}},
+		{name: "Int8", v: Int8{}.Set(-2), want: []byte{1, 0xfe}},
+		{name: "Int", v: Int{}.Set(1), want: []byte{1, 1, 0, 0, 0, 0, 0, 0, 0}},
+		{name: "Uint16", v: Uint16{}.Set(0x0102), want: []byte{1, 2, 1}},
+		{name: "Float32", v: Float32{}.Set(1), want: []byte{1, 0, 0, 0x80, 0x3f}},
+		{name: "Float64 unset", v: Float64{}, want: []byte{0}},
+	}
+
+	for _, tt := range tests {
+		got, err := tt.v.GobEncode()
+		if err != nil {
+			t.Errorf("TestGobEncode(%s): GobEncode() failed: %v", tt.name, err)
+			continue
+		}
+		if !bytes.Equal(got, tt.want) {
+			t.Errorf("TestGobEncode(%s): GobEncode() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestGobDecodeErrors(t *testing.T) {
+	t.Parallel()
+
+	type gobDecoder interface {
+		GobDecode([]byte) error
+	}
+
+	tests := []struct {
+		name string
+		v    gobDecoder
+		data []byte
+	}{
+		{name: "No data", v: &Bool{}, data: nil},
+		{name: "Invalid presence byte", v: &String{}, data: []byte{2, 'a'}},
+		{name: "Data after unset", v: &Int16{}, data: []byte{0, 1}},
+		{name: "Invalid Bool", v: &Bool{}, data: []byte{1, 2}},
+		{name: "Short Int32", v: &Int32{}, data: []byte{1, 1, 2}},
+		{name: "Long Uint8", v: &Uint8{}, data: []byte{1, 1, 2}},
+		{name: "Short Float64", v: &Float64{}, data: []byte{1, 0, 0, 0, 0}},
+	}
+
+	for _, tt := range tests {
+		if err := tt.v.GobDecode(tt.data); err == nil {
+			t.Errorf("TestGobDecodeErrors(%s): GobDecode(%v) succeeded, want error", tt.name, tt.data)
+		}
+	}
+}
@@ -91,7 +91,7 @@ func TestIntMarshalling(t *testing.T) {
 			jsonInput:  "",
 			wantInt:    0,
 			wantIsSet:  false,
-			wantOutput: "",
+			wantOutput: "null",
 		},
 		{
 			name:       "Unmarshal set Int",
@@ -152,3 +152,132 @@ func TestIntMarshalling(t *testing.T) {
 		}
 	}
 }
+
+func TestInt8Text(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		text     string
+		want     Int8
+		wantErr  bool
+		wantText string
+	}{
+		{name: "decimal", text: "-42", want: Int8{}.Set(-42), wantText: "-42"},
+		{name: "hex", text: "0x7f", want: Int8{}.Set(127), wantText: "127"},
+		{name: "binary with underscores", text: "-0b1000_0000", want: Int8{}.Set(-128), wantText: "-128"},
+		{name: "octal", text: "0o17", want: Int8{}.Set(15), wantText: "15"},
+		{name: "out of range", text: "128", wantErr: true},
+		{name: "fraction", text: "1.5", wantErr: true},
+		{name: "empty is unset", text: ""},
+	}
+
+	for _, tt := range tests {
+		var v Int8
+		err := v.UnmarshalText([]byte(tt.text))
+		switch {
+		case err == nil && tt.wantErr:
+			t.Errorf("TestInt8Text(%s): got err == nil, want err != nil", tt.name)
+			continue
+		case err != nil && !tt.wantErr:
+			t.Errorf("TestInt8Text(%s): got err == %s, want err == nil", tt.name, err)
+			continue
+		case err != nil:
+			if v.IsSet() {
+				t.Errorf("TestInt8Text(%s): IsSet() = true after error, want false", tt.name)
+			}
+			continue
+		}
+		if v != tt.want {
+			t.Errorf("TestInt8Text(%s): got %v(set %v), want %v(set %v)", tt.name, v.V(), v.IsSet(), tt.want.V(), tt.want.IsSet())
+		}
+
+		text, err := v.MarshalText()
+		if err != nil {
+			t.Fatalf("TestInt8Text(%s): MarshalText() failed: %v", tt.name, err)
+		}
+		if string(text) != tt.wantText {
+			t.Errorf("TestInt8Text(%s): MarshalText() = %q, want %q", tt.name, text, tt.wantText)
+		}
+	}
+
+	text, err := Int8{}.MarshalText()
+	if err != nil || len(text) != 0 {
+		t.Errorf("TestInt8Text(unset): MarshalText() = %q, %v, want empty text", text, err)
+	}
+	b, err := Int8{}.Set(-5).AppendText([]byte("x="))
+	if err != nil || string(b) != "x=-5" {
+		t.Errorf("TestInt8Text(append): AppendText() = %q, %v, want %q", b, err, "x=-5")
+	}
+}
+
+type stringifyConfig struct {
+	ID    Int64 `json:",string"`
+	Count Int64
+}
+
+func TestInt64Stringify(t *testing.T) {
+	t.Parallel()
+
+	const big = 1<<53 + 1
+	v := stringifyConfig{ID: Int64{}.Set(big), Count: Int64{}.Set(-big)}
+
+	tests := []struct {
+		name string
+		opts json.Options
+		want string
+	}{
+		{name: "Tag", want: `{"ID":"9007199254740993","Count":-9007199254740993}`},
+		{name: "StringifyNumbers", opts: json.StringifyNumbers(true), want: `{"ID":"9007199254740993","Count":"-9007199254740993"}`},
+		{name: "WithStringifyNumbers", opts: JSONOptions(WithStringifyNumbers(true)), want: `{"ID":"9007199254740993","Count":"-9007199254740993"}`},
+	}
+
+	for _, tt := range tests {
+		b, err := json.Marshal(v, tt.opts)
+		if err != nil {
+			t.Fatalf("TestInt64Stringify(%s): Marshal() failed: %v", tt.name, err)
+		}
+		if string(b) != tt.want {
+			t.Errorf("TestInt64Stringify(%s): Marshal() = %s, want %s", tt.name, b, tt.want)
+		}
+		var got stringifyConfig
+		if err := json.Unmarshal(b, &got, tt.opts); err != nil {
+			t.Fatalf("TestInt64Stringify(%s): Unmarshal() failed: %v", tt.name, err)
+		}
+		if got != v {
+			t.Errorf("TestInt64Stringify(%s): Unmarshal() = %+v, want %+v", tt.name, got, v)
+		}
+	}
+
+	// Numbers are still accepted for stringified fields, but not strings for other fields.
+	var got stringifyConfig
+	if err := json.Unmarshal([]byte(`{"ID":1}`), &got); err != nil || got.ID.V() != 1 {
+		t.Errorf("TestInt64Stringify: Unmarshal(number) = %v, got %v, want 1", err, got.ID.V())
+	}
+	if err := json.Unmarshal([]byte(`{"Count":"1"}`), &got); err == nil {
+		t.Errorf("TestInt64Stringify: Unmarshal(string) into untagged field succeeded, want error")
+	}
+	if err := json.Unmarshal([]byte(`{"ID":"0x1"}`), &got); err == nil {
+		t.Errorf("TestInt64Stringify: Unmarshal(\"0x1\") succeeded, want error")
+	}
+}
+
+// TestInt64StringifyDefaults is not parallel, as it changes the package level options.
+func TestInt64StringifyDefaults(t *testing.T) {
+	defer SetDefaults()
+
+	SetDefaults(WithStringifyNumbers(true))
+
+	v := Int64{}.Set(-1 << 62)
+	b, err := v.MarshalJSON()
+	if err != nil || string(b) != `"-4611686018427387904"` {
+		t.Errorf("TestInt64StringifyDefaults: MarshalJSON() = %s, %v, want \"-4611686018427387904\"", b, err)
+	}
+	var got Int64
+	if err := got.UnmarshalJSON(b); err != nil || got != v {
+		t.Errorf("TestInt64StringifyDefaults: UnmarshalJSON(%s) = %v, got %v, want %v", b, err, got.V(), v.V())
+	}
+	if err := got.UnmarshalJSON([]byte(`"9223372036854775808"`)); err == nil {
+		t.Errorf("TestInt64StringifyDefaults: UnmarshalJSON() out of range succeeded, want error")
+	}
+}
@@ -0,0 +1,177 @@
+// Package codec holds the reflection helpers that the msgpack and cbor packages share to encode structs
+// of isset types.
+package codec
+
+import (
+	"encoding"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/gostdlib/types/isset"
+)
+
+// Field is an exported struct field that is encoded and decoded.
+type Field struct {
+	// Name is the key of the field, the name in the struct tag or else the field name.
+	Name string
+	// Index is the index of the field in the struct.
+	Index int
+	// Type is the type of the field.
+	Type reflect.Type
+	// OmitEmpty is true if the struct tag has the omitempty option.
+	OmitEmpty bool
+}
+
+// StructFields returns the fields of the struct type t that are encoded and decoded. The names and options
+// are read from the struct tag with the key, and fields tagged with "-" are skipped.
+func StructFields(t reflect.Type, key string) []Field {
+	var fields []Field
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		tag := sf.Tag.Get(key)
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if name == "" {
+			name = sf.Name
+		}
+		fields = append(fields, Field{Name: name, Index: i, Type: sf.Type, OmitEmpty: opts == "omitempty"})
+	}
+	return fields
+}
+
+// issetPkg is the package path of the isset types.
+var issetPkg = reflect.TypeFor[isset.Bool]().PkgPath()
+
+// Isset holds the indexes of the methods of an isset type that a codec uses. The types are accessed
+// through their methods, as their fields are unexported.
+type Isset struct {
+	// Elem is the type of the value, which V returns and Set takes.
+	Elem reflect.Type
+	// Nullable is true for an isset.Nullable.
+	Nullable bool
+
+	isSet, v, set, unset, isNull, setNull int
+}
+
+// IssetOf returns the Isset for t and if t is an isset type, including isset.Of and isset.Nullable.
+func IssetOf(t reflect.Type) (Isset, bool) {
+	if t.PkgPath() != issetPkg || t.Kind() != reflect.Struct {
+		return Isset{}, false
+	}
+	isSet, ok1 := t.MethodByName("IsSet")
+	v, ok2 := t.MethodByName("V")
+	set, ok3 := t.MethodByName("Set")
+	unset, ok4 := t.MethodByName("Unset")
+	if !ok1 || !ok2 || !ok3 || !ok4 || v.Type.NumOut() != 1 {
+		return Isset{}, false
+	}
+	m := Isset{Elem: v.Type.Out(0), isSet: isSet.Index, v: v.Index, set: set.Index, unset: unset.Index}
+
+	isNull, ok1 := t.MethodByName("IsNull")
+	setNull, ok2 := t.MethodByName("SetNull")
+	if ok1 && ok2 {
+		m.Nullable, m.isNull, m.setNull = true, isNull.Index, setNull.Index
+	}
+	return m, true
+}
+
+// Get returns the value of the isset value v and if it is set.
+func (m Isset) Get(v reflect.Value) (reflect.Value, bool) {
+	if !v.Method(m.isSet).Call(nil)[0].Bool() {
+		return reflect.Value{}, false
+	}
+	return v.Method(m.v).Call(nil)[0], true
+}
+
+// IsNull reports if the isset value v is an isset.Nullable that is null.
+func (m Isset) IsNull(v reflect.Value) bool {
+	return m.Nullable && v.Method(m.isNull).Call(nil)[0].Bool()
+}
+
+// Set sets the isset value v to elem.
+func (m Isset) Set(v, elem reflect.Value) {
+	v.Set(v.Method(m.set).Call([]reflect.Value{elem})[0])
+}
+
+// Unset makes the isset value v unset, which is absent for an isset.Nullable.
+func (m Isset) Unset(v reflect.Value) {
+	v.Set(v.Method(m.unset).Call(nil)[0])
+}
+
+// Null makes the isset value v null if it is an isset.Nullable, or else unset.
+func (m Isset) Null(v reflect.Value) {
+	if !m.Nullable {
+		m.Unset(v)
+		return
+	}
+	v.Set(v.Method(m.setNull).Call(nil)[0])
+}
+
+// Method is how a codec encodes a type through its methods instead of its fields.
+type Method int
+
+const (
+	// MethodNone is for types that the codec encodes as usual.
+	MethodNone Method = iota
+	// MethodBinary is for types encoded with their encoding.BinaryMarshaler and encoding.BinaryUnmarshaler
+	// methods, as a binary string.
+	MethodBinary
+	// MethodText is for types encoded with their encoding.TextMarshaler and encoding.TextUnmarshaler methods,
+	// as a text string.
+	MethodText
+	// MethodMissing is for types that need methods to be encoded but have neither pair.
+	MethodMissing
+)
+
+var (
+	timeType              = reflect.TypeFor[time.Time]()
+	binaryMarshalerType   = reflect.TypeFor[encoding.BinaryMarshaler]()
+	binaryUnmarshalerType = reflect.TypeFor[encoding.BinaryUnmarshaler]()
+	textMarshalerType     = reflect.TypeFor[encoding.TextMarshaler]()
+	textUnmarshalerType   = reflect.TypeFor[encoding.TextUnmarshaler]()
+)
+
+// MethodOf returns the Method for t. Structs with fields but no exported ones, such as netip.Addr, would
+// be encoded as an empty map, so they use their binary methods, else their text methods. Other types and
+// time.Time, which the codecs encode with their own time formats, use MethodNone.
+func MethodOf(t reflect.Type) Method {
+	if t.Kind() != reflect.Struct || t.NumField() == 0 || t == timeType {
+		return MethodNone
+	}
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).IsExported() {
+			return MethodNone
+		}
+	}
+	p := reflect.PointerTo(t)
+	switch {
+	case t.Implements(binaryMarshalerType) && p.Implements(binaryUnmarshalerType):
+		return MethodBinary
+	case t.Implements(textMarshalerType) && p.Implements(textUnmarshalerType):
+		return MethodText
+	}
+	return MethodMissing
+}
+
+// Marshal returns the encoding of v with the methods of m, which is MethodBinary or MethodText.
+func (m Method) Marshal(v reflect.Value) ([]byte, error) {
+	if m == MethodBinary {
+		return v.Interface().(encoding.BinaryMarshaler).MarshalBinary()
+	}
+	return v.Interface().(encoding.TextMarshaler).MarshalText()
+}
+
+// Unmarshal decodes data into v, which is addressable, with the methods of m, which is MethodBinary or
+// MethodText.
+func (m Method) Unmarshal(data []byte, v reflect.Value) error {
+	if m == MethodBinary {
+		return v.Addr().Interface().(encoding.BinaryUnmarshaler).UnmarshalBinary(data)
+	}
+	return v.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText(data)
+}
@@ -0,0 +1,148 @@
+package codec
+
+import (
+	"net/netip"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gostdlib/types/isset"
+)
+
+func TestStructFields(t *testing.T) {
+	t.Parallel()
+
+	type s struct {
+		A       int
+		B       int `k:"b"`
+		C       int `k:",omitempty"`
+		D       int `k:"-"`
+		E       int `other:"e"`
+		private int
+	}
+
+	want := []Field{
+		{Name: "A", Index: 0, Type: reflect.TypeFor[int]()},
+		{Name: "b", Index: 1, Type: reflect.TypeFor[int]()},
+		{Name: "C", Index: 2, Type: reflect.TypeFor[int](), OmitEmpty: true},
+		{Name: "E", Index: 4, Type: reflect.TypeFor[int]()},
+	}
+	if got := StructFields(reflect.TypeFor[s](), "k"); !reflect.DeepEqual(got, want) {
+		t.Errorf("TestStructFields: got %+v, want %+v", got, want)
+	}
+}
+
+func TestIsset(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		t        reflect.Type
+		ok       bool
+		elem     reflect.Type
+		nullable bool
+	}{
+		{name: "Int", t: reflect.TypeFor[isset.Int](), ok: true, elem: reflect.TypeFor[int]()},
+		{name: "Time", t: reflect.TypeFor[isset.Time](), ok: true, elem: reflect.TypeFor[time.Time]()},
+		{name: "Of", t: reflect.TypeFor[isset.Of[[]string]](), ok: true, elem: reflect.TypeFor[[]string]()},
+		{name: "Nullable", t: reflect.TypeFor[isset.Nullable[bool]](), ok: true, elem: reflect.TypeFor[bool](), nullable: true},
+		{name: "DecodeError", t: reflect.TypeFor[isset.DecodeError]()},
+		{name: "pointer", t: reflect.TypeFor[*isset.Int]()},
+		{name: "int", t: reflect.TypeFor[int]()},
+	}
+
+	for _, tt := range tests {
+		m, ok := IssetOf(tt.t)
+		if ok != tt.ok {
+			t.Errorf("TestIsset(%s): IssetOf() ok = %v, want %v", tt.name, ok, tt.ok)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		if m.Elem != tt.elem {
+			t.Errorf("TestIsset(%s): Elem = %s, want %s", tt.name, m.Elem, tt.elem)
+		}
+		if m.Nullable != tt.nullable {
+			t.Errorf("TestIsset(%s): Nullable = %v, want %v", tt.name, m.Nullable, tt.nullable)
+		}
+	}
+
+	var n isset.Nullable[bool]
+	v := reflect.ValueOf(&n).Elem()
+	m, _ := IssetOf(v.Type())
+
+	m.Set(v, reflect.ValueOf(true))
+	if e, ok := m.Get(v); !ok || !e.Bool() {
+		t.Errorf("TestIsset(Nullable): Get() after Set(true) = %v, %v, want true, true", e, ok)
+	}
+	m.Null(v)
+	if !n.IsNull() || !m.IsNull(v) {
+		t.Errorf("TestIsset(Nullable): Null() did not make the value null")
+	}
+	m.Unset(v)
+	if !n.IsAbsent() || m.IsNull(v) {
+		t.Errorf("TestIsset(Nullable): Unset() did not make the value absent")
+	}
+
+	var i isset.Int
+	v = reflect.ValueOf(&i).Elem()
+	m, _ = IssetOf(v.Type())
+	m.Set(v, reflect.ValueOf(1))
+	m.Null(v)
+	if i.IsSet() || m.IsNull(v) {
+		t.Errorf("TestIsset(Int): Null() = %+v, want unset", i)
+	}
+}
+
+// textOnly has no exported fields and only the text methods.
+type textOnly struct{ s string }
+
+func (t textOnly) MarshalText() ([]byte, error) { return []byte(t.s), nil }
+
+func (t *textOnly) UnmarshalText(b []byte) error {
+	t.s = string(b)
+	return nil
+}
+
+func TestMethod(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		v    any
+		want Method
+	}{
+		{name: "netip.Addr", v: netip.MustParseAddr("::1"), want: MethodBinary},
+		{name: "text methods", v: textOnly{s: "a"}, want: MethodText},
+		{name: "no methods", v: struct{ n int }{}, want: MethodMissing},
+		{name: "time.Time", v: time.Time{}, want: MethodNone},
+		{name: "exported field", v: struct{ N int }{}, want: MethodNone},
+		{name: "empty struct", v: struct{}{}, want: MethodNone},
+		{name: "int", v: 1, want: MethodNone},
+	}
+
+	for _, tt := range tests {
+		m := MethodOf(reflect.TypeOf(tt.v))
+		if m != tt.want {
+			t.Errorf("TestMethod(%s): MethodOf() = %d, want %d", tt.name, m, tt.want)
+			continue
+		}
+		if m != MethodBinary && m != MethodText {
+			continue
+		}
+		b, err := m.Marshal(reflect.ValueOf(tt.v))
+		if err != nil {
+			t.Errorf("TestMethod(%s): Marshal() failed: %v", tt.name, err)
+			continue
+		}
+		got := reflect.New(reflect.TypeOf(tt.v)).Elem()
+		if err := m.Unmarshal(b, got); err != nil {
+			t.Errorf("TestMethod(%s): Unmarshal() failed: %v", tt.name, err)
+			continue
+		}
+		if got.Interface() != tt.v {
+			t.Errorf("TestMethod(%s): Unmarshal() = %v, want %v", tt.name, got, tt.v)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+// Package codectest holds the tests of the isset behavior that the msgpack and cbor packages share. Each
+// package runs them with its Marshal and Unmarshal functions, and keeps the tests of its wire format.
+package codectest
+
+import (
+	"net/netip"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gostdlib/types/isset"
+)
+
+type point struct {
+	X, Y int
+}
+
+// config has a field of each isset type, with the struct tags of both codecs.
+type config struct {
+	Name    isset.String          `msgpack:"name" cbor:"name"`
+	Port    isset.Uint16          `msgpack:"port" cbor:"port"`
+	Offset  isset.Int8            `msgpack:"offset" cbor:"offset"`
+	Ratio   isset.Float32         `msgpack:"ratio" cbor:"ratio"`
+	Battery isset.Float64         `msgpack:"battery" cbor:"battery"`
+	Debug   isset.Bool            `msgpack:"debug" cbor:"debug"`
+	Start   isset.Time            `msgpack:"start" cbor:"start"`
+	Timeout isset.Duration        `msgpack:"timeout" cbor:"timeout"`
+	Key     isset.Bytes           `msgpack:"key" cbor:"key"`
+	Point   isset.Of[point]       `msgpack:"point" cbor:"point"`
+	Addr    isset.Of[netip.Addr]  `msgpack:"addr" cbor:"addr"`
+	Parent  isset.Nullable[int64] `msgpack:"parent,omitempty" cbor:"parent,omitempty"`
+	Retries isset.Int             `msgpack:"retries,omitempty" cbor:"retries,omitempty"`
+	Next    *config               `msgpack:"next,omitempty" cbor:"next,omitempty"`
+}
+
+// nils has values that are set to nil, and one that is unset.
+type nils struct {
+	Bytes  isset.Bytes
+	Slice  isset.Of[[]int]
+	Map    isset.Nullable[map[string]int]
+	Absent isset.Of[[]int]
+}
+
+// Isset checks that the isset types round-trip through marshal and unmarshal, including unset and null
+// values and values set to nil, and that the values a codec cannot encode return errors. It is called
+// by the TestIsset of each codec.
+func Isset(t *testing.T, marshal func(v any) ([]byte, error), unmarshal func(data []byte, v any) error) {
+	tests := []struct {
+		name string
+		in   config
+	}{
+		{name: "Unset"},
+		{
+			name: "Zero values",
+			in: config{
+				Name:    isset.String{}.Set(""),
+				Port:    isset.Uint16{}.Set(0),
+				Debug:   isset.Bool{}.Set(false),
+				Key:     isset.Bytes{}.Set([]byte{}),
+				Parent:  isset.Nullable[int64]{}.SetNull(),
+				Retries: isset.Int{}.Set(0),
+			},
+		},
+		{
+			name: "Values",
+			in: config{
+				Name:    isset.String{}.Set("api"),
+				Port:    isset.Uint16{}.Set(8080),
+				Offset:  isset.Int8{}.Set(-8),
+				Ratio:   isset.Float32{}.Set(0.25),
+				Battery: isset.Float64{}.Set(0.1),
+				Debug:   isset.Bool{}.Set(true),
+				Start:   isset.Time{}.Set(time.Date(2025, 1, 2, 3, 4, 5, 6, time.UTC)),
+				Timeout: isset.Duration{}.Set(time.Minute),
+				Key:     isset.Bytes{}.Set([]byte{1, 2}),
+				Point:   isset.Of[point]{}.Set(point{X: 1, Y: -1}),
+				Addr:    isset.Of[netip.Addr]{}.Set(netip.MustParseAddr("2001:db8::1")),
+				Parent:  isset.Nullable[int64]{}.Set(7),
+				Retries: isset.Int{}.Set(3),
+				Next:    &config{Name: isset.String{}.Set("next")},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		b, err := marshal(tt.in)
+		if err != nil {
+			t.Errorf("TestIsset(%s): Marshal() failed: %v", tt.name, err)
+			continue
+		}
+		var got config
+		if err := unmarshal(b, &got); err != nil {
+			t.Errorf("TestIsset(%s): Unmarshal() failed: %v", tt.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.in) {
+			t.Errorf("TestIsset(%s): got %+v, want %+v", tt.name, got, tt.in)
+		}
+	}
+
+	// A set value must not be encoded like an unset one.
+	in := nils{
+		Bytes: isset.Bytes{}.Set(nil),
+		Slice: isset.Of[[]int]{}.Set(nil),
+		Map:   isset.Nullable[map[string]int]{}.Set(nil),
+	}
+	b, err := marshal(in)
+	if err != nil {
+		t.Fatalf("TestIsset(Set(nil)): Marshal() failed: %v", err)
+	}
+	var got nils
+	if err := unmarshal(b, &got); err != nil {
+		t.Fatalf("TestIsset(Set(nil)): Unmarshal() failed: %v", err)
+	}
+	if !got.Bytes.IsSet() || len(got.Bytes.V()) != 0 || !got.Slice.IsSet() || len(got.Slice.V()) != 0 || !got.Map.IsSet() || len(got.Map.V()) != 0 {
+		t.Errorf("TestIsset(Set(nil)): got %+v, want Bytes, Slice and Map set and empty", got)
+	}
+	if got.Absent.IsSet() {
+		t.Errorf("TestIsset(Set(nil)): got Absent set, want unset")
+	}
+
+	errs := []struct {
+		name string
+		v    any
+	}{
+		// A struct without exported fields or methods would otherwise be encoded as an empty map.
+		{name: "Of without methods", v: isset.Of[struct{ n int }]{}.Set(struct{ n int }{n: 1})},
+		{name: "Of set to a nil pointer", v: isset.Of[*int]{}.Set(nil)},
+	}
+	for _, tt := range errs {
+		if _, err := marshal(tt.v); err == nil {
+			t.Errorf("TestIsset(%s): Marshal() succeeded, want error", tt.name)
+		}
+	}
+}
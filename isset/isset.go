@@ -27,7 +27,55 @@ This is useful when the zero value is a valid value and you need to check if the
 nil checks on pointers to basic types and nil values that can cause panics.
 
 This type of thing is common with configuration files where you want to know if a value was set or not. This
-package supports JSON marshalling and unmarshalling using the v1 an v2 JSON packages.
+package supports JSON marshalling and unmarshalling using the v1 an v2 JSON packages. All types also implement
+encoding.TextMarshaler and encoding.TextUnmarshaler, so they work with text based formats such as TOML and YAML
+and as map keys. For caches and encoding/gob, all types implement encoding.BinaryMarshaler and
+encoding.BinaryUnmarshaler with a compact format that keeps both the value and if it was set: a presence byte
+followed by the value, with numbers sized like encoding/binary. Bool, String and the numeric types also implement
+gob.GobEncoder and gob.GobDecoder with the same format. For encoding/xml, all types implement the element and
+attribute marshalers: an unset value writes nothing and an absent element or attribute leaves the value unset.
+
+Unset values are encoded as JSON null by both the v1 and v2 methods. Every type has an IsZero method that
+reports if the value is unset, so struct fields tagged with `omitzero` are omitted when unset with the v2 json
+package and with encoding/json in Go 1.24 and later. YAML encoders such as gopkg.in/yaml.v3 also call IsZero
+for the `omitempty` struct tag option:
+
+	type Config struct {
+		Timeout isset.Duration `json:",omitzero"` // Omitted when unset.
+		Retries isset.Int                         // null when unset.
+	}
+
+The UnsetPolicy set with SetDefaults or passed with JSONOptions selects the value written for unset values
+in fields without `omitzero`: null, or the zero value of the type.
+
+JSON values that cannot be decoded, such as a string for an Int or a number that does not fit in an Int8, are
+reported with a *DecodeError. With the v2 json package it has the JSON Pointer and input offset of the value.
+
+JSON encoding and decoding can be adjusted with an Option, such as WithUnsetPolicy or WithLenient for hand
+written configuration files. Options are passed to JSONOptions for use with the v2 json package, or set for
+the whole program with SetDefaults, which also applies to the v1 methods:
+
+	err := json.Unmarshal(data, &cfg, isset.JSONOptions(isset.WithLenient(true)))
+
+The v2 methods also honor the json.Options that apply to the underlying type, so a field behaves as the
+primitive would in a struct marshalled with the v2 json package. This includes json.StringifyNumbers and the
+`,string` struct tag option for the numeric types and these `format` struct tag options:
+
+	Int*, Uint*       format:hex encodes a JSON string such as "0x1f"
+	Float32, Float64  format:nonfinite encodes NaN and infinities as strings
+	Time, Duration    the formats supported for time.Time and time.Duration, such as format:unix
+	Bytes             format:base64, base64url, base32, base32hex, base16 and hex
+
+Other format flags are rejected. Of[T] passes the options to T.
+
+The v2 methods above are named MarshalJSONV2 and UnmarshalJSONV2, as used by github.com/go-json-experiment/json.
+
+With Go 1.27 and later, which enable the jsonv2 GOEXPERIMENT by default, the types also implement the
+MarshalJSONTo and UnmarshalJSONFrom methods of encoding/json/v2. These methods use the options set with
+SetDefaults and honor json.StringifyNumbers and the `,string` struct tag option. encoding/json/v2 does not
+support the `format` struct tag option. The methods are left out with GOEXPERIMENT=nojsonv2, and with
+Go 1.25 and 1.26 even with GOEXPERIMENT=jsonv2, as their file requires go1.27: go.mod declares an older
+language version and Go 1.27 only allows the encoding/json/v2 API in files that require go1.27.
 
 Note: The types in this package do not use pointers, but return values. This is to avoid heap allocations
 and to keep the values on the stack.
@@ -54,18 +102,35 @@ Example:
 	// This will return 0.
 	fmt.Println(ms.Val.V())
 
-Benchmarks:
+Benchmarks, the output of go test -bench 'BenchmarkInt$' -benchmem on one machine:
+
+	goos: linux
+	goarch: amd64
+	pkg: github.com/gostdlib/types/isset
+	cpu: Intel(R) Xeon(R) Processor
+	BenchmarkInt/Set                1000000000               0.6026 ns/op          0 B/op          0 allocs/op
+	BenchmarkInt/Unset              1000000000               0.4958 ns/op          0 B/op          0 allocs/op
+	BenchmarkInt/V                  1000000000               0.5253 ns/op          0 B/op          0 allocs/op
+	BenchmarkInt/IsSet              1000000000               0.4790 ns/op          0 B/op          0 allocs/op
+	BenchmarkInt/MarshalJSON          28515162                48.25 ns/op         24 B/op          1 allocs/op
+	BenchmarkInt/MarshalJSONV2        27263578                46.10 ns/op          0 B/op          0 allocs/op
+	BenchmarkInt/UnmarshalJSON        45339200                25.50 ns/op          0 B/op          0 allocs/op
+	BenchmarkInt/UnmarshalJSONV2       3029058               442.3 ns/op          64 B/op          1 allocs/op
 
-	BenchmarkInt/Set-10                             1000000000               0.3142 ns/op          0 B/op          0 allocs/op
-	BenchmarkInt/Unset-10                           1000000000               0.3140 ns/op          0 B/op          0 allocs/op
-	BenchmarkInt/V-10                               1000000000               0.3156 ns/op          0 B/op          0 allocs/op
-	BenchmarkInt/IsSet-10                           1000000000               0.3148 ns/op          0 B/op          0 allocs/op
-	BenchmarkInt/MarshalJSON-10                     14314348                82.78 ns/op           16 B/op          2 allocs/op
-	BenchmarkInt/MarshalJSONV2-10                   76317876                15.72 ns/op            0 B/op          0 allocs/op
-	BenchmarkInt/UnmarshalJSON-10                   14442656                82.24 ns/op           16 B/op          2 allocs/op
-	BenchmarkInt/UnmarshalJSONV2-10                  3822488               309.4 ns/op            64 B/op          1 allocs/op
+The v1 MarshalJSON and UnmarshalJSON methods of Bool, String and the numeric types format and parse the JSON
+directly with strconv, without going through a json package. Decoding a Bool or a number does not allocate
+and encoding allocates only the returned slice (see BenchmarkV1 and TestV1Allocs). On the same machine, the
+previous implementation took 167.0 ns/op with 2 allocs/op for MarshalJSON and 249.2 ns/op with 2 allocs/op
+for UnmarshalJSON.
+Every type also has an AppendJSON method that writes the same encoding as MarshalJSON into a caller
+provided buffer, which lets custom encoders stream values without the intermediate slice. Of and Nullable
+still encode a set value into a new slice first, which allocates.
 
-Benchmark note: I expect BenchmarkInt/UnmarshalJSONV2-10 time of 309.4 ns/op to be significantly lower on a real system.
+The v2 methods check the options (WithUnsetPolicy, StringifyNumbers and the `format` struct tag option),
+which costs time: on the same machine, MarshalJSONV2 took 31.90 ns/op and UnmarshalJSONV2 418.8 ns/op
+without them, with the same allocations.
+
+Benchmark note: I expect BenchmarkInt/UnmarshalJSONV2 to be significantly lower on a real system.
 Testing this is a little funky because you have to re-create the JSON decoder each time. Even with starting and
 stopping the timer in the test, the time is still higher than I would expect in the real world.
 I think this is due to the test harness and not the actual performance of the code.
@@ -73,11 +138,29 @@ I think this is due to the test harness and not the actual performance of the co
 Note: This does not use a single generic type because the json unmarshalling in the v2 package required type detection at runtime.
 By not using a generic version, we already know what broad type we are dealing with and can avoid the type detection.
 This allows us to have lower allocations with JSON encoding/decoding.
+
+For user defined types (structs, enumerations, netip.Addr, ...) there is the generic Of[T]. It delegates
+JSON encoding/decoding to the JSON methods of T when it has them and only falls back to the reflection
+based json package when it does not:
+
+	type MyStruct struct {
+		Addr isset.Of[netip.Addr]
+	}
 */
 package isset
 
 import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"unicode/utf8"
+	"unique"
 	"unsafe"
+
+	"github.com/go-json-experiment/json"
+	"github.com/go-json-experiment/json/jsontext"
 )
 
 // bytesToStr converts a byte slice to a string without copying the data.
@@ -88,3 +171,252 @@ func bytesToStr(b []byte) string {
 	}
 	return unsafe.String(unsafe.SliceData(b), l)
 }
+
+// unquote returns the JSON string data unquoted. A string without escape sequences, the common case,
+// is converted with a single allocation. If intern is set, the string is interned with unique.Make,
+// which only allocates for strings it has not seen.
+func unquote(data []byte, intern bool) (string, error) {
+	if n := len(data); n >= 2 && data[0] == '"' && data[n-1] == '"' {
+		s := data[1 : n-1]
+		simple, ascii := true, true
+		for _, c := range s {
+			if c < 0x20 || c == '"' || c == '\\' {
+				simple = false
+				break
+			}
+			if c >= utf8.RuneSelf {
+				ascii = false
+			}
+		}
+		if simple && (ascii || utf8.Valid(s)) {
+			if intern {
+				return unique.Make(bytesToStr(s)).Value(), nil
+			}
+			return string(s), nil
+		}
+	}
+	b, err := jsontext.AppendUnquote(nil, data)
+	if err != nil {
+		return "", err
+	}
+	if intern {
+		return unique.Make(bytesToStr(b)).Value(), nil
+	}
+	return bytesToStr(b), nil
+}
+
+// bitSize returns the size of T in bits, as used by the strconv parse functions.
+func bitSize[T any]() int {
+	var zero T
+	return int(unsafe.Sizeof(zero)) * 8
+}
+
+// parseInt parses the number s in the given base into a T, where base 0 accepts the syntax of Go
+// integer literals. Unlike a conversion from int64, it reports an error for a value that does not fit
+// in T or that has a fraction.
+func parseInt[T ~int | ~int8 | ~int16 | ~int32 | ~int64](s string, base int) (T, error) {
+	n, err := strconv.ParseInt(s, base, bitSize[T]())
+	if err != nil {
+		return 0, numberError[T](s, err)
+	}
+	return T(n), nil
+}
+
+// parseUint parses the number s in the given base into a T, where base 0 accepts the syntax of Go
+// integer literals. Unlike a conversion from uint64, it reports an error for a value that does not fit
+// in T, that has a fraction or that is negative.
+func parseUint[T ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64](s string, base int) (T, error) {
+	n, err := strconv.ParseUint(s, base, bitSize[T]())
+	if err != nil {
+		return 0, numberError[T](s, err)
+	}
+	return T(n), nil
+}
+
+// parseFloat parses the number s into a T. Unlike a conversion from float64, it reports an
+// error for a value that does not fit in T instead of returning an infinity.
+func parseFloat[T ~float32 | ~float64](s string) (T, error) {
+	f, err := strconv.ParseFloat(s, bitSize[T]())
+	if err != nil {
+		return 0, numberError[T](s, err)
+	}
+	return T(f), nil
+}
+
+// parseHex parses s, a hexadecimal number with an optional sign and 0x prefix as written for the
+// `format:hex` struct tag option, with parse, which is parseInt or parseUint.
+func parseHex[T any](s string, parse func(string, int) (T, error)) (T, error) {
+	neg := len(s) > 0 && s[0] == '-'
+	digits := s
+	if neg {
+		digits = s[1:]
+	}
+	if len(digits) < 2 || digits[0] != '0' || (digits[1] != 'x' && digits[1] != 'X') {
+		return parse(s, 16)
+	}
+	if neg {
+		return parse("-"+digits[2:], 16)
+	}
+	return parse(digits[2:], 16)
+}
+
+// appendHex appends u as a hexadecimal number with a 0x prefix, as written for the `format:hex`
+// struct tag option. If neg is set, a minus sign is added.
+func appendHex(b []byte, u uint64, neg bool) []byte {
+	if neg {
+		b = append(b, '-')
+	}
+	b = append(b, "0x"...)
+	return strconv.AppendUint(b, u, 16)
+}
+
+// appendFloat appends f formatted as a JSON number with the given bit size. It matches the v2 json
+// package, which uses the shortest representation and, like ES6, an exponent only for very small or
+// large values.
+func appendFloat(b []byte, f float64, bits int) []byte {
+	abs := math.Abs(f)
+	format := byte('f')
+	if abs != 0 {
+		if bits == 64 && (abs < 1e-6 || abs >= 1e21) || bits == 32 && (float32(abs) < 1e-6 || float32(abs) >= 1e21) {
+			format = 'e'
+		}
+	}
+	b = strconv.AppendFloat(b, f, format, -1, bits)
+	if format == 'e' {
+		// Clean up e-09 to e-9.
+		n := len(b)
+		if n >= 4 && b[n-4] == 'e' && b[n-3] == '-' && b[n-2] == '0' {
+			b[n-2] = b[n-1]
+			b = b[:n-1]
+		}
+	}
+	return b
+}
+
+// isNumber reports if s is a JSON number, as required for a number inside a JSON string when
+// numbers are stringified.
+func isNumber(s string) bool {
+	i := 0
+	if i < len(s) && s[i] == '-' {
+		i++
+	}
+	switch {
+	case i < len(s) && s[i] == '0':
+		i++
+	case i < len(s) && s[i] >= '1' && s[i] <= '9':
+		i = skipDigits(s, i)
+	default:
+		return false
+	}
+	if i < len(s) && s[i] == '.' {
+		j := skipDigits(s, i+1)
+		if j == i+1 {
+			return false
+		}
+		i = j
+	}
+	if i < len(s) && (s[i] == 'e' || s[i] == 'E') {
+		i++
+		if i < len(s) && (s[i] == '+' || s[i] == '-') {
+			i++
+		}
+		j := skipDigits(s, i)
+		if j == i {
+			return false
+		}
+		i = j
+	}
+	return i == len(s)
+}
+
+// skipDigits returns the index of the first byte at or after i in s that is not a decimal digit.
+func skipDigits(s string, i int) int {
+	for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+		i++
+	}
+	return i
+}
+
+// invalidFormatError returns the error for a `format` struct tag option that typ does not support.
+func invalidFormatError(typ, format string) error {
+	return fmt.Errorf("invalid format flag %q for %s", format, typ)
+}
+
+// numberError converts err from a strconv parse function for the number s into an error describing
+// why s cannot be decoded into a T.
+func numberError[T any](s string, err error) error {
+	var zero T
+	switch {
+	case errors.Is(err, strconv.ErrRange):
+		return fmt.Errorf("value %s out of range", s)
+	case len(s) > 0 && s[0] == '-' && isUnsigned(zero):
+		return fmt.Errorf("negative value %s for unsigned type", s)
+	}
+	if f, ferr := strconv.ParseFloat(s, 64); ferr == nil && f != math.Trunc(f) {
+		return fmt.Errorf("fractional value %s for integer type", s)
+	}
+	return fmt.Errorf("invalid number %s", s)
+}
+
+// isUnsigned reports if v is an unsigned integer.
+func isUnsigned(v any) bool {
+	switch reflect.ValueOf(v).Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return true
+	}
+	return false
+}
+
+// The json.Options type does not expose the `format` struct tag option, so formatOf reads it from the
+// Format and FormatDepth fields of the jsonopts.Struct that the options point to, and assumes that
+// FormatDepth is one more than the StackDepth of the Encoder or Decoder. Both are details of
+// github.com/go-json-experiment/json v0.0.0-20241230001524-0240acd0e023, the version in go.mod, and must be
+// checked when it is upgraded. TestFormatOf fails if the fields are not found.
+var (
+	defaultOpts      = json.DefaultOptionsV2()
+	optsType         = reflect.TypeOf(defaultOpts)
+	formatIndex      []int
+	formatDepthIndex []int
+)
+
+func init() {
+	if optsType.Kind() != reflect.Pointer || optsType.Elem().Kind() != reflect.Struct {
+		return
+	}
+	if f, ok := optsType.Elem().FieldByName("Format"); ok && f.Type.Kind() == reflect.String {
+		formatIndex = f.Index
+	}
+	if f, ok := optsType.Elem().FieldByName("FormatDepth"); ok && f.Type.Kind() == reflect.Int {
+		formatDepthIndex = f.Index
+	}
+}
+
+// errFormatUnsupported is returned by the v2 methods when they cannot read the `format` struct tag option
+// from options other than json.DefaultOptionsV2. The json package passes the options of its Encoder or
+// Decoder, so encoding and decoding with it fails instead of ignoring the format.
+var errFormatUnsupported = errors.New("isset: cannot read the format struct tag option from the json options of this version of github.com/go-json-experiment/json")
+
+// formatOf returns the `format` struct tag option that the v2 json package passed down in opts
+// for the value being encoded or decoded at the given stack depth. An empty string is returned
+// if no format applies. It returns errFormatUnsupported for options other than the defaults if the
+// fields holding the format were not found, so that the format is not silently ignored.
+func formatOf(opts json.Options, depth int) (string, error) {
+	// The default options never hold a format, so skip the reflection for them.
+	if opts == nil || opts == defaultOpts {
+		return "", nil
+	}
+	if formatIndex == nil || formatDepthIndex == nil {
+		return "", errFormatUnsupported
+	}
+	v := reflect.ValueOf(opts)
+	if !v.IsValid() || v.Type() != optsType || v.IsNil() {
+		return "", nil
+	}
+	s := v.Elem()
+	// The json package records the depth of the Encoder/Decoder state machine, which is one
+	// more than what StackDepth() reports.
+	if int(s.FieldByIndex(formatDepthIndex).Int()) != depth+1 {
+		return "", nil
+	}
+	return s.FieldByIndex(formatIndex).String(), nil
+}
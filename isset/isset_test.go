@@ -2,8 +2,14 @@ package isset
 
 import (
 	"bytes"
+	"encoding"
+	stdjson "encoding/json"
 	"io"
+	"math"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/go-json-experiment/json"
 	"github.com/go-json-experiment/json/jsontext"
@@ -12,6 +18,315 @@ import (
 var out []byte
 var err error
 
+// stdOmitZero reports if encoding/json supports the `omitzero` struct tag option (Go 1.24+).
+var stdOmitZero = func() bool {
+	b, _ := stdjson.Marshal(struct {
+		X int `json:",omitzero"`
+	}{})
+	return string(b) == "{}"
+}()
+
+func TestUnsetEncoding(t *testing.T) {
+	t.Parallel()
+
+	type omitted struct {
+		Bool     Bool     `json:",omitzero"`
+		String   String   `json:",omitzero"`
+		Int      Int      `json:",omitzero"`
+		Uint8    Uint8    `json:",omitzero"`
+		Float32  Float32  `json:",omitzero"`
+		Time     Time     `json:",omitzero"`
+		Duration Duration `json:",omitzero"`
+		Bytes    Bytes    `json:",omitzero"`
+		Of       Of[int]  `json:",omitzero"`
+	}
+	type nulled struct {
+		Bool     Bool
+		String   String
+		Int      Int
+		Uint8    Uint8
+		Float32  Float32
+		Time     Time
+		Duration Duration
+		Bytes    Bytes
+		Of       Of[int]
+	}
+
+	tests := []struct {
+		name string
+		v    any
+		want string
+	}{
+		{
+			name: "Unset omitzero fields are omitted",
+			v:    omitted{},
+			want: `{}`,
+		},
+		{
+			name: "Set omitzero fields are encoded",
+			v: omitted{
+				Bool:     Bool{}.Set(false),
+				String:   String{}.Set(""),
+				Int:      Int{}.Set(0),
+				Uint8:    Uint8{}.Set(0),
+				Float32:  Float32{}.Set(0),
+				Time:     Time{}.Set(time.Time{}),
+				Duration: Duration{}.Set(0),
+				Bytes:    Bytes{}.Set(nil),
+				Of:       Of[int]{}.Set(0),
+			},
+			want: `{"Bool":false,"String":"","Int":0,"Uint8":0,"Float32":0,"Time":"0001-01-01T00:00:00Z","Duration":"0s","Bytes":"","Of":0}`,
+		},
+		{
+			name: "Unset fields are null",
+			v:    nulled{},
+			want: `{"Bool":null,"String":null,"Int":null,"Uint8":null,"Float32":null,"Time":null,"Duration":null,"Bytes":null,"Of":null}`,
+		},
+	}
+
+	for _, tt := range tests {
+		got, err := json.Marshal(tt.v)
+		if err != nil {
+			t.Fatalf("TestUnsetEncoding(%s)(v2) failed: %v", tt.name, err)
+		}
+		if string(got) != tt.want {
+			t.Errorf("TestUnsetEncoding(%s)(v2) = %s, want %s", tt.name, got, tt.want)
+		}
+
+		if !stdOmitZero {
+			continue
+		}
+		got, err = stdjson.Marshal(tt.v)
+		if err != nil {
+			t.Fatalf("TestUnsetEncoding(%s)(v1) failed: %v", tt.name, err)
+		}
+		if string(got) != tt.want {
+			t.Errorf("TestUnsetEncoding(%s)(v1) = %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
+
+// numberDecoder is implemented by pointers to the numeric types in this package.
+type numberDecoder interface {
+	UnmarshalJSON([]byte) error
+	IsSet() bool
+}
+
+func TestDecodeNumberChecks(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		dst     func() numberDecoder
+		src     string
+		want    any
+		wantErr bool
+	}{
+		{name: "Int8 max", dst: func() numberDecoder { return new(Int8) }, src: "127", want: int8(127)},
+		{name: "Int8 min", dst: func() numberDecoder { return new(Int8) }, src: "-128", want: int8(-128)},
+		{name: "Int8 out of range", dst: func() numberDecoder { return new(Int8) }, src: "300", wantErr: true},
+		{name: "Int8 negative out of range", dst: func() numberDecoder { return new(Int8) }, src: "-129", wantErr: true},
+		{name: "Int fraction", dst: func() numberDecoder { return new(Int) }, src: "1.5", wantErr: true},
+		{name: "Int exponent", dst: func() numberDecoder { return new(Int) }, src: "1e2", wantErr: true},
+		{name: "Int64 out of range", dst: func() numberDecoder { return new(Int64) }, src: "9223372036854775808", wantErr: true},
+		{name: "Uint8 max", dst: func() numberDecoder { return new(Uint8) }, src: "255", want: uint8(255)},
+		{name: "Uint8 out of range", dst: func() numberDecoder { return new(Uint8) }, src: "256", wantErr: true},
+		{name: "Uint negative", dst: func() numberDecoder { return new(Uint) }, src: "-1", wantErr: true},
+		{name: "Uint32 fraction", dst: func() numberDecoder { return new(Uint32) }, src: "0.5", wantErr: true},
+		{name: "Uint64 max", dst: func() numberDecoder { return new(Uint64) }, src: "18446744073709551615", want: uint64(18446744073709551615)},
+		{name: "Float32", dst: func() numberDecoder { return new(Float32) }, src: "1.5", want: float32(1.5)},
+		{name: "Float32 out of range", dst: func() numberDecoder { return new(Float32) }, src: "1e39", wantErr: true},
+		{name: "Float64 out of range", dst: func() numberDecoder { return new(Float64) }, src: "1e309", wantErr: true},
+		{name: "Int string", dst: func() numberDecoder { return new(Int) }, src: `"1"`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		decoders := []struct {
+			name   string
+			decode func(numberDecoder) error
+		}{
+			{"v1", func(d numberDecoder) error { return d.UnmarshalJSON([]byte(tt.src)) }},
+			{"v2", func(d numberDecoder) error { return json.Unmarshal([]byte(tt.src), d) }},
+		}
+		for _, dec := range decoders {
+			dst := tt.dst()
+			err := dec.decode(dst)
+			switch {
+			case err == nil && tt.wantErr:
+				t.Errorf("TestDecodeNumberChecks(%s)(%s): got err == nil, want err != nil", tt.name, dec.name)
+				continue
+			case err != nil && !tt.wantErr:
+				t.Errorf("TestDecodeNumberChecks(%s)(%s): got err == %s, want err == nil", tt.name, dec.name, err)
+				continue
+			case err != nil:
+				if dst.IsSet() {
+					t.Errorf("TestDecodeNumberChecks(%s)(%s): IsSet() = true after error, want false", tt.name, dec.name)
+				}
+				continue
+			}
+			if got := valueOf(dst); !dst.IsSet() || got != tt.want {
+				t.Errorf("TestDecodeNumberChecks(%s)(%s): got %v(%T)(set %v), want %v(%T)", tt.name, dec.name, got, got, dst.IsSet(), tt.want, tt.want)
+			}
+		}
+	}
+}
+
+// TestFormatOf fails if the options struct of the json package no longer has the fields that formatOf
+// reads, as the v2 methods would then return errFormatUnsupported for any options but the defaults.
+func TestFormatOf(t *testing.T) {
+	t.Parallel()
+
+	if formatIndex == nil {
+		t.Errorf("TestFormatOf: no string field Format in %s", optsType)
+	}
+	if formatDepthIndex == nil {
+		t.Errorf("TestFormatOf: no int field FormatDepth in %s", optsType)
+	}
+	if got, err := formatOf(json.DefaultOptionsV2(), 0); got != "" || err != nil {
+		t.Errorf("TestFormatOf: formatOf(DefaultOptionsV2()) = %q, %v, want empty", got, err)
+	}
+}
+
+// TestFormatOfUnsupported is not parallel, as it changes the fields that formatOf reads.
+func TestFormatOfUnsupported(t *testing.T) {
+	saved := formatIndex
+	formatIndex = nil
+	defer func() { formatIndex = saved }()
+
+	v := struct {
+		N Int `json:",format:hex"`
+	}{N: Int{}.Set(31)}
+	if _, err := json.Marshal(v); err == nil || !strings.Contains(err.Error(), errFormatUnsupported.Error()) {
+		t.Errorf("TestFormatOfUnsupported: Marshal() = %v, want %v", err, errFormatUnsupported)
+	}
+	var buf bytes.Buffer
+	if err := (Int{}).Set(31).MarshalJSONV2(jsontext.NewEncoder(&buf), json.DefaultOptionsV2()); err != nil {
+		t.Errorf("TestFormatOfUnsupported: MarshalJSONV2(DefaultOptionsV2()) failed: %v", err)
+	}
+}
+
+func TestV2Options(t *testing.T) {
+	t.Parallel()
+
+	type hex struct {
+		Int  Int16  `json:",format:hex"`
+		Uint Uint32 `json:",format:hex"`
+	}
+	type floats struct {
+		F32 Float32
+		F64 Float64
+	}
+	type times struct {
+		Time     Time     `json:",format:unix"`
+		Date     Time     `json:",format:DateOnly"`
+		Duration Duration `json:",format:sec"`
+	}
+
+	ts := time.Unix(1700000000, 0).UTC()
+
+	tests := []struct {
+		name string
+		v    any
+		opts json.Options
+		want string
+	}{
+		{
+			name: "Hex",
+			v:    &hex{Int: Int16{}.Set(-255), Uint: Uint32{}.Set(0xdeadbeef)},
+			want: `{"Int":"-0xff","Uint":"0xdeadbeef"}`,
+		},
+		{
+			name: "Hex unset",
+			v:    &hex{},
+			want: `{"Int":null,"Uint":null}`,
+		},
+		{
+			name: "Float32 precision",
+			v:    &floats{F32: Float32{}.Set(0.1), F64: Float64{}.Set(1e21)},
+			want: `{"F32":0.1,"F64":1e+21}`,
+		},
+		{
+			name: "Float StringifyNumbers",
+			v:    &floats{F32: Float32{}.Set(-2.5), F64: Float64{}.Set(1e-7)},
+			opts: json.StringifyNumbers(true),
+			want: `{"F32":"-2.5","F64":"1e-7"}`,
+		},
+		{
+			name: "Time and Duration formats",
+			v:    &times{Time: Time{}.Set(ts), Date: Time{}.Set(ts.Truncate(24 * time.Hour)), Duration: Duration{}.Set(1500 * time.Millisecond)},
+			want: `{"Time":1700000000,"Date":"2023-11-14","Duration":1.5}`,
+		},
+		{
+			name: "Time and Duration formats unset",
+			v:    &times{},
+			want: `{"Time":null,"Date":null,"Duration":null}`,
+		},
+	}
+
+	for _, tt := range tests {
+		b, err := json.Marshal(tt.v, tt.opts)
+		if err != nil {
+			t.Errorf("TestV2Options(%s): Marshal() failed: %v", tt.name, err)
+			continue
+		}
+		if string(b) != tt.want {
+			t.Errorf("TestV2Options(%s): Marshal() = %s, want %s", tt.name, b, tt.want)
+		}
+
+		got := reflect.New(reflect.TypeOf(tt.v).Elem()).Interface()
+		if err := json.Unmarshal(b, got, tt.opts); err != nil {
+			t.Errorf("TestV2Options(%s): Unmarshal() failed: %v", tt.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.v) {
+			t.Errorf("TestV2Options(%s): Unmarshal() = %+v, want %+v", tt.name, got, tt.v)
+		}
+	}
+}
+
+func TestV2OptionsErrors(t *testing.T) {
+	t.Parallel()
+
+	type badFormats struct {
+		Bool   Bool    `json:",format:hex"`
+		String String  `json:",format:hex"`
+		Int    Int     `json:",format:base64"`
+		Float  Float64 `json:",format:hex"`
+	}
+
+	tests := []struct {
+		name string
+		src  string
+		dst  any
+	}{
+		{name: "Bool format", src: `{"Bool":true}`, dst: new(badFormats)},
+		{name: "String format", src: `{"String":"a"}`, dst: new(badFormats)},
+		{name: "Int format", src: `{"Int":1}`, dst: new(badFormats)},
+		{name: "Float format", src: `{"Float":1}`, dst: new(badFormats)},
+		{name: "Hex number", src: `{"Int":255}`, dst: new(struct {
+			Int Int `json:",format:hex"`
+		})},
+		{name: "Hex out of range", src: `{"Int":"0x100"}`, dst: new(struct {
+			Int Int8 `json:",format:hex"`
+		})},
+		{name: "Stringified not a number", src: `"0x10"`, dst: new(Float64)},
+		{name: "Unknown member in Of", src: `{"X":1,"Z":2}`, dst: new(Of[point])},
+	}
+
+	for _, tt := range tests {
+		opts := json.JoinOptions(json.StringifyNumbers(true), json.RejectUnknownMembers(true))
+		if err := json.Unmarshal([]byte(tt.src), tt.dst, opts); err == nil {
+			t.Errorf("TestV2OptionsErrors(%s): Unmarshal() succeeded, want error", tt.name)
+		}
+	}
+
+	for _, v := range []any{badFormats{Bool: Bool{}.Set(true)}, badFormats{Int: Int{}.Set(1)}, badFormats{Float: Float64{}.Set(1)}} {
+		if _, err := json.Marshal(v); err == nil {
+			t.Errorf("TestV2OptionsErrors: Marshal(%+v) succeeded, want error", v)
+		}
+	}
+}
+
 func BenchmarkInt(b *testing.B) {
 	b.Run("Set", func(b *testing.B) {
 		b.ReportAllocs()
@@ -66,11 +381,12 @@ func BenchmarkInt(b *testing.B) {
 		}
 	})
 
+	data := []byte("42")
 	b.Run("UnmarshalJSON", func(b *testing.B) {
 		b.ReportAllocs()
 		for i := 0; i < b.N; i++ {
 			var x Int
-			err = x.UnmarshalJSON([]byte("42"))
+			err = x.UnmarshalJSON(data)
 		}
 	})
 
@@ -87,3 +403,245 @@ func BenchmarkInt(b *testing.B) {
 		}
 	})
 }
+
+// TestV1Allocs is not parallel, as testing.AllocsPerRun counts the allocations of all goroutines.
+func TestV1Allocs(t *testing.T) {
+	if testing.CoverMode() != "" {
+		t.Skip("coverage instrumentation changes allocations")
+	}
+
+	var (
+		b  Bool
+		i  Int64
+		u  Uint16
+		f  Float64
+		s  String
+		tf = []byte("true")
+		n  = []byte("-42")
+		p  = []byte("8080")
+		fl = []byte("1.5")
+		st = []byte(`"hello"`)
+	)
+
+	tests := []struct {
+		name string
+		f    func()
+		want float64
+	}{
+		{name: "Bool.UnmarshalJSON", f: func() { err = b.UnmarshalJSON(tf) }},
+		{name: "Int64.UnmarshalJSON", f: func() { err = i.UnmarshalJSON(n) }},
+		{name: "Uint16.UnmarshalJSON", f: func() { err = u.UnmarshalJSON(p) }},
+		{name: "Float64.UnmarshalJSON", f: func() { err = f.UnmarshalJSON(fl) }},
+		// The string must be allocated, as data is owned by the caller.
+		{name: "String.UnmarshalJSON", f: func() { err = s.UnmarshalJSON(st) }, want: 1},
+		// The returned slice is the only allocation.
+		{name: "Bool.MarshalJSON", f: func() { out, err = b.MarshalJSON() }, want: 1},
+		{name: "Int64.MarshalJSON", f: func() { out, err = i.MarshalJSON() }, want: 1},
+		{name: "Uint16.MarshalJSON", f: func() { out, err = u.MarshalJSON() }, want: 1},
+		{name: "Float64.MarshalJSON", f: func() { out, err = f.MarshalJSON() }, want: 1},
+		{name: "String.MarshalJSON", f: func() { out, err = s.MarshalJSON() }, want: 1},
+	}
+
+	for _, tt := range tests {
+		if got := testing.AllocsPerRun(100, tt.f); got != tt.want {
+			t.Errorf("TestV1Allocs(%s): got %v allocs, want %v", tt.name, got, tt.want)
+		}
+		if err != nil {
+			t.Errorf("TestV1Allocs(%s): got err == %s, want err == nil", tt.name, err)
+		}
+	}
+}
+
+// TestV2NumberAllocs checks that the v2 methods decode numbers without allocating. It is not parallel, as
+// testing.AllocsPerRun counts the allocations of all goroutines.
+func TestV2NumberAllocs(t *testing.T) {
+	if testing.CoverMode() != "" {
+		t.Skip("coverage instrumentation changes allocations")
+	}
+
+	// Each run reads the next number of a long array, so the decoder has the data buffered.
+	data := []byte("[" + strings.Repeat("42,", 1000) + "42]")
+	opts := json.DefaultOptionsV2()
+
+	var (
+		i Int64
+		u Uint16
+		f Float64
+	)
+	tests := []struct {
+		name string
+		f    func(dec *jsontext.Decoder) error
+	}{
+		{name: "Int64", f: func(dec *jsontext.Decoder) error { return i.UnmarshalJSONV2(dec, opts) }},
+		{name: "Uint16", f: func(dec *jsontext.Decoder) error { return u.UnmarshalJSONV2(dec, opts) }},
+		{name: "Float64", f: func(dec *jsontext.Decoder) error { return f.UnmarshalJSONV2(dec, opts) }},
+	}
+
+	for _, tt := range tests {
+		dec := jsontext.NewDecoder(bytes.NewReader(data))
+		if _, err := dec.ReadToken(); err != nil {
+			t.Fatalf("TestV2NumberAllocs(%s): ReadToken() failed: %v", tt.name, err)
+		}
+		if got := testing.AllocsPerRun(100, func() { err = tt.f(dec) }); got != 0 {
+			t.Errorf("TestV2NumberAllocs(%s): got %v allocs, want 0", tt.name, got)
+		}
+		if err != nil {
+			t.Errorf("TestV2NumberAllocs(%s): got err == %s, want err == nil", tt.name, err)
+		}
+	}
+}
+
+func BenchmarkV1(b *testing.B) {
+	benchmarks := []struct {
+		name string
+		v    interface {
+			stdjson.Marshaler
+			stdjson.Unmarshaler
+		}
+		data []byte
+	}{
+		{name: "Bool", v: new(Bool), data: []byte("true")},
+		{name: "Int64", v: new(Int64), data: []byte("-9007199254740993")},
+		{name: "Uint8", v: new(Uint8), data: []byte("255")},
+		{name: "Float64", v: new(Float64), data: []byte("3.14159")},
+		{name: "String", v: new(String), data: []byte(`"hello, world"`)},
+	}
+
+	for _, bm := range benchmarks {
+		b.Run(bm.name+"/UnmarshalJSON", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				err = bm.v.UnmarshalJSON(bm.data)
+			}
+		})
+		b.Run(bm.name+"/MarshalJSON", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				out, err = bm.v.MarshalJSON()
+			}
+		})
+	}
+}
+
+// jsonAppender is implemented by all the types in this package.
+type jsonAppender interface {
+	stdjson.Marshaler
+	AppendJSON([]byte) ([]byte, error)
+}
+
+func TestAppendJSON(t *testing.T) {
+	t.Parallel()
+
+	ts := time.Date(2024, 12, 30, 15, 4, 5, 6, time.UTC)
+
+	tests := []struct {
+		name string
+		v    jsonAppender
+		want string
+	}{
+		{name: "Bool", v: Bool{}.Set(true), want: "true"},
+		{name: "Bool unset", v: Bool{}, want: "null"},
+		{name: "String", v: String{}.Set("a\"b\n"), want: `"a\"b\n"`},
+		{name: "Int8", v: Int8{}.Set(-128), want: "-128"},
+		{name: "Uint64", v: Uint64{}.Set(math.MaxUint64), want: "18446744073709551615"},
+		{name: "Float32", v: Float32{}.Set(0.1), want: "0.1"},
+		{name: "Float64 unset", v: Float64{}, want: "null"},
+		{name: "Time", v: Time{}.Set(ts), want: `"2024-12-30T15:04:05.000000006Z"`},
+		{name: "Duration", v: Duration{}.Set(90 * time.Second), want: `"1m30s"`},
+		{name: "Bytes", v: Bytes{}.Set([]byte("hi")), want: `"aGk="`},
+		{name: "Of", v: Of[point]{}.Set(point{X: 1, Y: 2}), want: `{"X":1,"Y":2}`},
+		{name: "Nullable", v: Nullable[int]{}.Set(3), want: "3"},
+		{name: "Nullable null", v: Nullable[int]{}.SetNull(), want: "null"},
+	}
+
+	for _, tt := range tests {
+		got, err := tt.v.AppendJSON([]byte(`{"x":`))
+		if err != nil {
+			t.Errorf("TestAppendJSON(%s): AppendJSON() failed: %v", tt.name, err)
+			continue
+		}
+		if want := `{"x":` + tt.want; string(got) != want {
+			t.Errorf("TestAppendJSON(%s): AppendJSON() = %s, want %s", tt.name, got, want)
+		}
+		m, err := tt.v.MarshalJSON()
+		if err != nil || string(m) != tt.want {
+			t.Errorf("TestAppendJSON(%s): MarshalJSON() = %s, %v, want %s", tt.name, m, err, tt.want)
+		}
+	}
+
+	b, err := Float64{}.Set(math.Inf(1)).AppendJSON([]byte("x"))
+	if err == nil || string(b) != "x" {
+		t.Errorf("TestAppendJSON(Float64 +Inf): AppendJSON() = %q, %v, want %q and an error", b, err, "x")
+	}
+}
+
+// TestAppendJSONAllocs is not parallel, as testing.AllocsPerRun counts the allocations of all goroutines.
+func TestAppendJSONAllocs(t *testing.T) {
+	if testing.CoverMode() != "" {
+		t.Skip("coverage instrumentation changes allocations")
+	}
+
+	values := []jsonAppender{
+		Bool{}.Set(true),
+		Bool{},
+		String{}.Set("hello"),
+		Int64{}.Set(-1 << 60),
+		Uint16{}.Set(8080),
+		Float64{}.Set(3.25),
+		Time{}.Set(time.Date(2024, 12, 30, 15, 4, 5, 0, time.UTC)),
+		Bytes{}.Set([]byte("hello")),
+	}
+
+	buf := make([]byte, 0, 128)
+	for _, v := range values {
+		got := testing.AllocsPerRun(100, func() {
+			out, err = v.AppendJSON(buf[:0])
+		})
+		if got != 0 {
+			t.Errorf("TestAppendJSONAllocs(%T): got %v allocs, want 0", v, got)
+		}
+	}
+}
+
+// TestTextUnset checks that an unset value survives MarshalText and UnmarshalText, as TOML and YAML
+// encoders use them. String and Bytes decode empty text as a set empty value.
+func TestTextUnset(t *testing.T) {
+	t.Parallel()
+
+	type textValue interface {
+		encoding.TextMarshaler
+		encoding.TextUnmarshaler
+		IsSet() bool
+	}
+
+	tests := []struct {
+		name    string
+		unset   encoding.TextMarshaler
+		into    textValue
+		wantSet bool
+	}{
+		{name: "Bool", unset: Bool{}, into: &Bool{}},
+		{name: "Int8", unset: Int8{}, into: &Int8{}},
+		{name: "Uint16", unset: Uint16{}, into: &Uint16{}},
+		{name: "Float64", unset: Float64{}, into: &Float64{}},
+		{name: "Time", unset: Time{}, into: &Time{}},
+		{name: "Duration", unset: Duration{}, into: &Duration{}},
+		{name: "String", unset: String{}, into: &String{}, wantSet: true},
+		{name: "Bytes", unset: Bytes{}, into: &Bytes{}, wantSet: true},
+	}
+
+	for _, tt := range tests {
+		text, err := tt.unset.MarshalText()
+		if err != nil {
+			t.Errorf("TestTextUnset(%s): MarshalText() failed: %v", tt.name, err)
+			continue
+		}
+		if err := tt.into.UnmarshalText(text); err != nil {
+			t.Errorf("TestTextUnset(%s): UnmarshalText(%q) failed: %v", tt.name, text, err)
+			continue
+		}
+		if tt.into.IsSet() != tt.wantSet {
+			t.Errorf("TestTextUnset(%s): IsSet() = %v, want %v", tt.name, tt.into.IsSet(), tt.wantSet)
+		}
+	}
+}
@@ -0,0 +1,228 @@
+//go:build goexperiment.jsonv2 && go1.27
+
+package isset
+
+import (
+	"encoding/json/jsontext"
+	json "encoding/json/v2"
+	"errors"
+)
+
+// This file implements the json.MarshalerTo and json.UnmarshalerFrom interfaces of encoding/json/v2,
+// which replaced the MarshalerV2 and UnmarshalerV2 interfaces of github.com/go-json-experiment/json.
+// The values are encoded and decoded like the v1 methods do, using the options set with SetDefaults
+// and the json.StringifyNumbers option of the encoder or decoder, which the `,string` struct tag
+// option also sets. The `format` struct tag option is not supported by encoding/json/v2.
+// The go1.27 build constraint raises the language version of this file above the one in go.mod,
+// which the encoding/json/v2 API requires.
+
+// optionsFrom returns the options set with SetDefaults with the json.StringifyNumbers option in opts
+// applied.
+func optionsFrom(opts json.Options) options {
+	o := *getDefaults()
+	if v, _ := json.GetOption(opts, json.StringifyNumbers); v {
+		o.stringify = true
+	}
+	return o
+}
+
+// appender is implemented by the types in this package that encode through appendJSON.
+type appender interface {
+	appendJSON(dst []byte, o *options) ([]byte, error)
+}
+
+// marshalTo writes v to enc with the options of enc.
+func marshalTo[T appender](enc *jsontext.Encoder, v T) error {
+	o := optionsFrom(enc.Options())
+	var buf [64]byte
+	b, err := v.appendJSON(buf[:0], &o)
+	if err != nil {
+		return err
+	}
+	return enc.WriteValue(b)
+}
+
+// decoder is implemented by pointers to the types in this package that decode through unmarshalJSON.
+type decoder interface {
+	unmarshalJSON(data []byte, o *options) error
+}
+
+// unmarshalFrom reads the next value from dec into v with the options of dec.
+func unmarshalFrom[P decoder](dec *jsontext.Decoder, v P) error {
+	o := optionsFrom(dec.Options())
+	val, err := dec.ReadValue()
+	if err != nil {
+		return err
+	}
+	return atDecoder(dec, v.unmarshalJSON(val, &o))
+}
+
+// atDecoder sets the location of a *DecodeError in err to the value just read from dec.
+func atDecoder(dec *jsontext.Decoder, err error) error {
+	var de *DecodeError
+	if errors.As(err, &de) {
+		de.at(string(dec.StackPointer()), dec.InputOffset())
+	}
+	return err
+}
+
+// MarshalJSONTo implements the json.MarshalerTo interface.
+func (i Bool) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return marshalTo(enc, i)
+}
+
+// UnmarshalJSONFrom implements the json.UnmarshalerFrom interface.
+func (v *Bool) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	return unmarshalFrom(dec, v)
+}
+
+// MarshalJSONTo implements the json.MarshalerTo interface.
+func (i String) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return marshalTo(enc, i)
+}
+
+// UnmarshalJSONFrom implements the json.UnmarshalerFrom interface.
+func (v *String) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	o := optionsFrom(dec.Options())
+	val, err := dec.ReadValue()
+	if err != nil {
+		return err
+	}
+	if err := v.unmarshalJSON(val, &o); err != nil {
+		// The decoder only lets invalid UTF-8 through with AllowInvalidUTF8, which replaces it.
+		if allow, _ := json.GetOption(dec.Options(), jsontext.AllowInvalidUTF8); !allow || val.Kind() != '"' {
+			return atDecoder(dec, err)
+		}
+		b, _ := jsontext.AppendUnquote(nil, val)
+		*v = v.Set(string(b))
+	}
+	return nil
+}
+
+// MarshalJSONTo implements the json.MarshalerTo interface.
+func (i intType[T]) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return marshalTo(enc, i)
+}
+
+// UnmarshalJSONFrom implements the json.UnmarshalerFrom interface.
+func (v *intType[T]) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	return unmarshalFrom(dec, v)
+}
+
+// MarshalJSONTo implements the json.MarshalerTo interface.
+func (i uintType[T]) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return marshalTo(enc, i)
+}
+
+// UnmarshalJSONFrom implements the json.UnmarshalerFrom interface.
+func (v *uintType[T]) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	return unmarshalFrom(dec, v)
+}
+
+// MarshalJSONTo implements the json.MarshalerTo interface.
+func (i floatType[T]) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return marshalTo(enc, i)
+}
+
+// UnmarshalJSONFrom implements the json.UnmarshalerFrom interface.
+func (v *floatType[T]) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	return unmarshalFrom(dec, v)
+}
+
+// MarshalJSONTo implements the json.MarshalerTo interface.
+func (i Time) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return marshalTo(enc, i)
+}
+
+// UnmarshalJSONFrom implements the json.UnmarshalerFrom interface.
+func (v *Time) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	val, err := dec.ReadValue()
+	if err != nil {
+		return err
+	}
+	return atDecoder(dec, v.UnmarshalJSON(val))
+}
+
+// MarshalJSONTo implements the json.MarshalerTo interface.
+func (i Duration) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return marshalTo(enc, i)
+}
+
+// UnmarshalJSONFrom implements the json.UnmarshalerFrom interface.
+func (v *Duration) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	val, err := dec.ReadValue()
+	if err != nil {
+		return err
+	}
+	return atDecoder(dec, v.UnmarshalJSON(val))
+}
+
+// MarshalJSONTo implements the json.MarshalerTo interface.
+func (i Bytes) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return marshalTo(enc, i)
+}
+
+// UnmarshalJSONFrom implements the json.UnmarshalerFrom interface.
+func (v *Bytes) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	val, err := dec.ReadValue()
+	if err != nil {
+		return err
+	}
+	return atDecoder(dec, v.UnmarshalJSON(val))
+}
+
+// MarshalJSONTo implements the json.MarshalerTo interface. T is encoded with json.MarshalEncode,
+// which uses the JSON methods of T.
+func (i Of[T]) MarshalJSONTo(enc *jsontext.Encoder) error {
+	if getDefaults().writeNull(i.isSet) {
+		return enc.WriteToken(jsontext.Null)
+	}
+	return json.MarshalEncode(enc, &i.v)
+}
+
+// UnmarshalJSONFrom implements the json.UnmarshalerFrom interface. T is decoded with
+// json.UnmarshalDecode, which uses the JSON methods of T.
+func (v *Of[T]) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	if dec.PeekKind() == 'n' {
+		if _, err := dec.ReadToken(); err != nil {
+			return err
+		}
+		*v = v.Unset()
+		return nil
+	}
+
+	var t T
+	if err := json.UnmarshalDecode(dec, &t); err != nil {
+		return err
+	}
+	*v = v.Set(t)
+	return nil
+}
+
+// MarshalJSONTo implements the json.MarshalerTo interface. T is encoded with json.MarshalEncode,
+// which uses the JSON methods of T.
+func (i Nullable[T]) MarshalJSONTo(enc *jsontext.Encoder) error {
+	if i.state != nullSet {
+		return enc.WriteToken(jsontext.Null)
+	}
+	return json.MarshalEncode(enc, &i.v)
+}
+
+// UnmarshalJSONFrom implements the json.UnmarshalerFrom interface. T is decoded with
+// json.UnmarshalDecode, which uses the JSON methods of T.
+func (v *Nullable[T]) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	if dec.PeekKind() == 'n' {
+		if _, err := dec.ReadToken(); err != nil {
+			return err
+		}
+		*v = v.SetNull()
+		return nil
+	}
+
+	var t T
+	if err := json.UnmarshalDecode(dec, &t); err != nil {
+		return err
+	}
+	*v = v.Set(t)
+	return nil
+}
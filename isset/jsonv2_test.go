@@ -0,0 +1,145 @@
+//go:build goexperiment.jsonv2 && go1.27
+
+package isset
+
+import (
+	"encoding/json/jsontext"
+	json "encoding/json/v2"
+	"errors"
+	"testing"
+	"time"
+)
+
+var (
+	_ json.MarshalerTo     = Bool{}
+	_ json.UnmarshalerFrom = (*Bool)(nil)
+	_ json.MarshalerTo     = Int64{}
+	_ json.UnmarshalerFrom = (*Uint8)(nil)
+	_ json.MarshalerTo     = Of[point]{}
+	_ json.UnmarshalerFrom = (*Nullable[int])(nil)
+)
+
+type stdConfig struct {
+	Name    String          `json:"name"`
+	Port    Uint16          `json:"port"`
+	Offset  Int32           `json:"offset"`
+	Ratio   Float64         `json:"ratio"`
+	Debug   Bool            `json:"debug"`
+	Start   Time            `json:"start"`
+	Timeout Duration        `json:"timeout"`
+	Key     Bytes           `json:"key"`
+	Point   Of[point]       `json:"point"`
+	Parent  Nullable[int]   `json:"parent"`
+	ID      Int64           `json:"id,string"`
+	Unset   Int             `json:"unset"`
+	Omitted String          `json:"omitted,omitzero"`
+	Nested  Of[stdNestedID] `json:"nested"`
+}
+
+type stdNestedID struct {
+	ID Uint64 `json:"id"`
+}
+
+func TestStdJSONV2(t *testing.T) {
+	t.Parallel()
+
+	in := stdConfig{
+		Name:    String{}.Set("api"),
+		Port:    Uint16{}.Set(8080),
+		Offset:  Int32{}.Set(-5),
+		Ratio:   Float64{}.Set(0.25),
+		Debug:   Bool{}.Set(false),
+		Start:   Time{}.Set(time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)),
+		Timeout: Duration{}.Set(90 * time.Second),
+		Key:     Bytes{}.Set([]byte("hi")),
+		Point:   Of[point]{}.Set(point{X: 1, Y: 2}),
+		Parent:  Nullable[int]{}.SetNull(),
+		ID:      Int64{}.Set(9007199254740993),
+		Nested:  Of[stdNestedID]{}.Set(stdNestedID{ID: Uint64{}.Set(7)}),
+	}
+	want := `{"name":"api","port":8080,"offset":-5,"ratio":0.25,"debug":false,"start":"2025-01-02T03:04:05Z",` +
+		`"timeout":"1m30s","key":"aGk=","point":{"X":1,"Y":2},"parent":null,"id":"9007199254740993",` +
+		`"unset":null,"nested":{"id":7}}`
+
+	b, err := json.Marshal(in)
+	if err != nil {
+		t.Fatalf("TestStdJSONV2: Marshal() failed: %v", err)
+	}
+	if string(b) != want {
+		t.Fatalf("TestStdJSONV2: Marshal() =\n%s\nwant\n%s", b, want)
+	}
+
+	var got stdConfig
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatalf("TestStdJSONV2: Unmarshal() failed: %v", err)
+	}
+	switch {
+	case got.Name != in.Name, got.Port != in.Port, got.Offset != in.Offset, got.Ratio != in.Ratio,
+		got.Debug != in.Debug, !got.Start.V().Equal(in.Start.V()), got.Timeout != in.Timeout,
+		string(got.Key.V()) != "hi", got.Point != in.Point, !got.Parent.IsNull(), got.ID != in.ID,
+		got.Unset.IsSet(), got.Omitted.IsSet(), got.Nested.V().ID != in.Nested.V().ID:
+		t.Errorf("TestStdJSONV2: Unmarshal() = %+v, want %+v", got, in)
+	}
+}
+
+func TestStdJSONV2StringifyNumbers(t *testing.T) {
+	t.Parallel()
+
+	in := []Uint64{Uint64{}.Set(18446744073709551615)}
+	b, err := json.Marshal(in, json.StringifyNumbers(true))
+	if err != nil || string(b) != `["18446744073709551615"]` {
+		t.Errorf("TestStdJSONV2StringifyNumbers: Marshal() = %s, %v, want %s", b, err, `["18446744073709551615"]`)
+	}
+
+	var got []Uint64
+	if err := json.Unmarshal(b, &got, json.StringifyNumbers(true)); err != nil || len(got) != 1 || got[0] != in[0] {
+		t.Errorf("TestStdJSONV2StringifyNumbers: Unmarshal() = %v, %v, want %v", got, err, in)
+	}
+	if err := json.Unmarshal(b, &got); err == nil {
+		t.Errorf("TestStdJSONV2StringifyNumbers: Unmarshal() without StringifyNumbers succeeded, want error")
+	}
+}
+
+func TestStdJSONV2Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		data        string
+		wantPointer jsontext.Pointer
+		wantOffset  int64
+		wantKind    jsontext.Kind
+	}{
+		{name: "Wrong kind", data: `{"name":"api","port":"80"}`, wantPointer: "/port", wantOffset: 25, wantKind: '"'},
+		{name: "Out of range", data: `{"offset":3000000000}`, wantPointer: "/offset", wantOffset: 20, wantKind: '0'},
+		{name: "Bad time", data: `{"start":"yesterday"}`, wantPointer: "/start", wantOffset: 20, wantKind: '"'},
+	}
+
+	for _, tt := range tests {
+		var got stdConfig
+		err := json.Unmarshal([]byte(tt.data), &got)
+		var de *DecodeError
+		if !errors.As(err, &de) {
+			t.Errorf("TestStdJSONV2Errors(%s): got err %v, want a *DecodeError", tt.name, err)
+			continue
+		}
+		if string(de.Pointer) != string(tt.wantPointer) || de.Offset != tt.wantOffset || byte(de.Kind) != byte(tt.wantKind) {
+			t.Errorf("TestStdJSONV2Errors(%s): got pointer %q, offset %d, kind %v, want %q, %d, %v",
+				tt.name, de.Pointer, de.Offset, de.Kind, tt.wantPointer, tt.wantOffset, tt.wantKind)
+		}
+	}
+}
+
+func TestStdJSONV2InvalidUTF8(t *testing.T) {
+	t.Parallel()
+
+	data := []byte("\"a\xffb\"")
+
+	var s String
+	if err := json.Unmarshal(data, &s); err == nil {
+		t.Errorf("TestStdJSONV2InvalidUTF8: Unmarshal() succeeded, want error")
+	}
+	if err := json.Unmarshal(data, &s, jsontext.AllowInvalidUTF8(true)); err != nil || s.V() != "a�b" {
+		t.Errorf("TestStdJSONV2InvalidUTF8: Unmarshal(AllowInvalidUTF8) = %q, %v, want %q", s.V(), err, "a�b")
+	}
+}
@@ -0,0 +1,109 @@
+package isset
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-json-experiment/json/jsontext"
+)
+
+// This file implements the decoding of JSON strings into Bool and the numeric types when the lenient
+// option is set with WithLenient. In all cases an empty string decodes to an unset value.
+
+// setLenient sets i from the JSON string s. It accepts the spellings of strconv.ParseBool and
+// yes/no, y/n and on/off, ignoring case.
+func (i *Bool) setLenient(s string) error {
+	switch strings.ToLower(s) {
+	case "":
+		*i = i.Unset()
+	case "1", "t", "true", "y", "yes", "on":
+		*i = i.Set(true)
+	case "0", "f", "false", "n", "no", "off":
+		*i = i.Set(false)
+	default:
+		return fmt.Errorf("invalid boolean %q", s)
+	}
+	return nil
+}
+
+// setLenient sets i from the JSON string s. It accepts the syntax of Go integer literals, including
+// base prefixes such as 0x and underscores, except that a leading zero does not mean octal.
+func (i *intType[T]) setLenient(s string) error {
+	if s == "" {
+		*i = i.Unset()
+		return nil
+	}
+	n, err := parseInt[T](trimZeros(s), 0)
+	if err != nil {
+		return err
+	}
+	*i = i.Set(n)
+	return nil
+}
+
+// setLenient sets i from the JSON string s. It accepts the syntax of Go integer literals, including
+// base prefixes such as 0x and underscores, except that a leading zero does not mean octal.
+func (i *uintType[T]) setLenient(s string) error {
+	if s == "" {
+		*i = i.Unset()
+		return nil
+	}
+	n, err := parseUint[T](trimZeros(s), 0)
+	if err != nil {
+		return err
+	}
+	*i = i.Set(n)
+	return nil
+}
+
+// trimZeros removes the leading zeros of the integer s unless they start a base prefix such as 0x, so
+// that "010" is read as 10 like in a decimal number and not as 8 like in a Go octal literal.
+func trimZeros(s string) string {
+	sign := ""
+	if s != "" && (s[0] == '+' || s[0] == '-') {
+		sign, s = s[:1], s[1:]
+	}
+	if len(s) < 2 || s[0] != '0' {
+		return sign + s
+	}
+	switch s[1] {
+	case 'x', 'X', 'o', 'O', 'b', 'B':
+		return sign + s
+	}
+	s = strings.TrimLeft(s, "0")
+	switch {
+	case s == "":
+		s = "0"
+	case len(s) > 1 && s[0] == '_':
+		// An underscore may follow the zeros, as in "0_100".
+		s = s[1:]
+	}
+	return sign + s
+}
+
+// setLenient sets i from the JSON string s. It accepts the values strconv.ParseFloat accepts, but
+// only accepts NaN and infinities if nonFinite is set.
+func (i *floatType[T]) setLenient(s string, nonFinite bool) error {
+	if s == "" {
+		*i = i.Unset()
+		return nil
+	}
+	f, err := parseFloat[T](s)
+	if err != nil {
+		return err
+	}
+	if _, ok := nonFiniteName(float64(f)); ok && !nonFinite {
+		return fmt.Errorf("invalid number %q: non-finite values require WithNonFinite", s)
+	}
+	*i = i.Set(f)
+	return nil
+}
+
+// unquoteV1 unquotes the JSON string data passed to UnmarshalJSON and calls set with the result.
+func unquoteV1(data []byte, set func(string) error) error {
+	s, err := jsontext.AppendUnquote(nil, data)
+	if err != nil {
+		return err
+	}
+	return set(bytesToStr(s))
+}
@@ -0,0 +1,175 @@
+package isset
+
+import (
+	"math"
+	"testing"
+
+	"github.com/go-json-experiment/json"
+)
+
+type lenientConfig struct {
+	Bool    Bool
+	String  String
+	Int     Int
+	Int8    Int8
+	Uint16  Uint16
+	Float64 Float64
+}
+
+func TestLenient(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		src        string
+		want       lenientConfig
+		wantErr    bool
+		wantStrict bool // want the strict decoding to succeed with the same result
+	}{
+		{
+			name:       "Strict values",
+			src:        `{"Bool": true, "String": "a", "Int": -1, "Int8": 2, "Uint16": 3, "Float64": 1.5}`,
+			want:       lenientConfig{Bool{}.Set(true), String{}.Set("a"), Int{}.Set(-1), Int8{}.Set(2), Uint16{}.Set(3), Float64{}.Set(1.5)},
+			wantStrict: true,
+		},
+		{
+			name: "Bool spellings",
+			src:  `{"Bool": "Yes"}`,
+			want: lenientConfig{Bool: Bool{}.Set(true)},
+		},
+		{
+			name: "Bool off",
+			src:  `{"Bool": "OFF"}`,
+			want: lenientConfig{Bool: Bool{}.Set(false)},
+		},
+		{
+			name:    "Bool bad spelling",
+			src:     `{"Bool": "maybe"}`,
+			wantErr: true,
+		},
+		{
+			name: "Quoted numbers",
+			src:  `{"Int": "-42", "Uint16": "8080", "Float64": "2.5"}`,
+			want: lenientConfig{Int: Int{}.Set(-42), Uint16: Uint16{}.Set(8080), Float64: Float64{}.Set(2.5)},
+		},
+		{
+			name: "Go literals",
+			src:  `{"Int": "1_000_000", "Int8": "0x7f", "Uint16": "0b1010"}`,
+			want: lenientConfig{Int: Int{}.Set(1000000), Int8: Int8{}.Set(127), Uint16: Uint16{}.Set(10)},
+		},
+		{
+			name: "Leading zeros are decimal",
+			src:  `{"Int": "-010", "Int8": "0_7", "Uint16": "08080"}`,
+			want: lenientConfig{Int: Int{}.Set(-10), Int8: Int8{}.Set(7), Uint16: Uint16{}.Set(8080)},
+		},
+		{
+			name: "Zero and octal prefix",
+			src:  `{"Int": "000", "Int8": "0o17", "Uint16": "0x10"}`,
+			want: lenientConfig{Int: Int{}.Set(0), Int8: Int8{}.Set(15), Uint16: Uint16{}.Set(16)},
+		},
+		{
+			name:    "Leading zeros and underscore only",
+			src:     `{"Int": "0_"}`,
+			wantErr: true,
+		},
+		{
+			name:    "Quoted number out of range",
+			src:     `{"Int8": "0x80"}`,
+			wantErr: true,
+		},
+		{
+			name:    "Quoted negative for unsigned",
+			src:     `{"Uint16": "-1"}`,
+			wantErr: true,
+		},
+		{
+			name:    "Quoted NaN",
+			src:     `{"Float64": "nan"}`,
+			wantErr: true,
+		},
+		{
+			name:    "Quoted infinity",
+			src:     `{"Float64": "-Inf"}`,
+			wantErr: true,
+		},
+		{
+			name: "Empty strings are unset",
+			src:  `{"Bool": "", "String": "", "Int": "", "Float64": ""}`,
+			want: lenientConfig{},
+		},
+	}
+
+	for _, tt := range tests {
+		var got lenientConfig
+		err := json.Unmarshal([]byte(tt.src), &got, JSONOptions(WithLenient(true)))
+		switch {
+		case err == nil && tt.wantErr:
+			t.Errorf("TestLenient(%s): got err == nil, want err != nil", tt.name)
+			continue
+		case err != nil && !tt.wantErr:
+			t.Errorf("TestLenient(%s): got err == %s, want err == nil", tt.name, err)
+			continue
+		case err != nil:
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("TestLenient(%s): got %+v, want %+v", tt.name, got, tt.want)
+		}
+
+		var strict lenientConfig
+		err = json.Unmarshal([]byte(tt.src), &strict)
+		if (err == nil) != tt.wantStrict {
+			t.Errorf("TestLenient(%s): strict decoding got err == %v, want success == %v", tt.name, err, tt.wantStrict)
+		}
+	}
+}
+
+// TestLenientDefaults is not parallel, as it changes the package level options.
+func TestLenientDefaults(t *testing.T) {
+	defer SetDefaults()
+
+	var b Bool
+	if err := b.UnmarshalJSON([]byte(`"on"`)); err == nil {
+		t.Errorf("TestLenientDefaults: UnmarshalJSON(\"on\") without WithLenient succeeded, want error")
+	}
+
+	SetDefaults(WithLenient(true))
+
+	if err := b.UnmarshalJSON([]byte(`"on"`)); err != nil || !b.IsSet() || !b.V() {
+		t.Errorf("TestLenientDefaults: UnmarshalJSON(\"on\") = %v, got %v(set %v), want true(set true)", err, b.V(), b.IsSet())
+	}
+	var i Int16
+	if err := i.UnmarshalJSON([]byte(`"0x10"`)); err != nil || i.V() != 16 {
+		t.Errorf("TestLenientDefaults: UnmarshalJSON(\"0x10\") = %v, got %v, want 16", err, i.V())
+	}
+	u := Uint{}.Set(1)
+	if err := u.UnmarshalJSON([]byte(`""`)); err != nil || u.IsSet() {
+		t.Errorf("TestLenientDefaults: UnmarshalJSON(\"\") = %v, got set %v, want unset", err, u.IsSet())
+	}
+	s := String{}.Set("a")
+	if err := s.UnmarshalJSON([]byte(`""`)); err != nil || s.IsSet() {
+		t.Errorf("TestLenientDefaults: String.UnmarshalJSON(\"\") = %v, got set %v, want unset", err, s.IsSet())
+	}
+	var f Float32
+	if err := f.UnmarshalJSON([]byte(`"1e39"`)); err == nil || f.IsSet() {
+		t.Errorf("TestLenientDefaults: UnmarshalJSON(\"1e39\") = %v, got set %v, want error and unset", err, f.IsSet())
+	}
+	if err := f.UnmarshalJSON([]byte(`"inf"`)); err == nil || f.IsSet() {
+		t.Errorf("TestLenientDefaults: UnmarshalJSON(\"inf\") = %v, got set %v, want error and unset", err, f.IsSet())
+	}
+
+	SetDefaults(WithLenient(true), WithNonFinite(true))
+
+	if err := f.UnmarshalJSON([]byte(`"inf"`)); err != nil || !math.IsInf(float64(f.V()), 1) {
+		t.Errorf("TestLenientDefaults: UnmarshalJSON(\"inf\") with WithNonFinite = %v, got %v, want +Inf", err, f.V())
+	}
+
+	// The v2 methods use the defaults when no options are passed.
+	var c lenientConfig
+	if err := json.Unmarshal([]byte(`{"Bool": "yes", "Int": "7"}`), &c); err != nil {
+		t.Fatalf("TestLenientDefaults: Unmarshal() failed: %v", err)
+	}
+	if !c.Bool.V() || c.Int.V() != 7 {
+		t.Errorf("TestLenientDefaults: Unmarshal() got %+v, want Bool true and Int 7", c)
+	}
+}
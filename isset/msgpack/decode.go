@@ -0,0 +1,781 @@
+package msgpack
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/gostdlib/types/isset/internal/codec"
+)
+
+// decoder reads MessagePack values from data.
+type decoder struct {
+	data []byte
+	off  int
+	// depth is the number of arrays and maps that are being decoded.
+	depth int
+}
+
+// maxDepth is the maximum nesting of arrays and maps, the limit encoding/json uses. Deeper data returns an
+// error instead of overflowing the stack, which a program cannot recover from.
+const maxDepth = 10000
+
+// enter records that an array or map is decoded and returns an error if it is nested too deeply. leave
+// must be called when it is done.
+func (d *decoder) enter() error {
+	d.depth++
+	if d.depth > maxDepth {
+		return fmt.Errorf("msgpack: exceeded max depth of %d at offset %d", maxDepth, d.off)
+	}
+	return nil
+}
+
+// leave records that an array or map passed to enter is decoded.
+func (d *decoder) leave() {
+	d.depth--
+}
+
+// kind is the family of a MessagePack format.
+type kind uint8
+
+const (
+	kindNil kind = iota
+	kindBool
+	kindUint
+	kindInt
+	kindFloat32
+	kindFloat64
+	kindStr
+	kindBin
+	kindArray
+	kindMap
+	kindExt
+)
+
+// String implements fmt.Stringer.
+func (k kind) String() string {
+	switch k {
+	case kindNil:
+		return "nil"
+	case kindBool:
+		return "bool"
+	case kindUint, kindInt:
+		return "int"
+	case kindFloat32:
+		return "float 32"
+	case kindFloat64:
+		return "float 64"
+	case kindStr:
+		return "str"
+	case kindBin:
+		return "bin"
+	case kindArray:
+		return "array"
+	case kindMap:
+		return "map"
+	case kindExt:
+		return "ext"
+	}
+	return fmt.Sprintf("kind(%d)", k)
+}
+
+// head is the header of a MessagePack value. The data of a str, bin or ext and the elements of an
+// array or map follow it.
+type head struct {
+	kind kind
+	// u is the value of a kindUint or kindBool (1 for true), the bits of a float, the length of a str,
+	// bin or ext and the number of elements of an array or map.
+	u uint64
+	// i is the value of a kindInt, which is only used for negative values.
+	i int64
+	// ext is the type of a kindExt.
+	ext int8
+}
+
+// readHead reads the header of the next value.
+func (d *decoder) readHead() (head, error) {
+	if d.off >= len(d.data) {
+		return head{}, d.errEOF()
+	}
+	c := d.data[d.off]
+	d.off++
+
+	switch {
+	case c <= 0x7f:
+		return head{kind: kindUint, u: uint64(c)}, nil
+	case c >= 0xe0:
+		return head{kind: kindInt, i: int64(int8(c))}, nil
+	case c&0xf0 == codeFixMap:
+		return head{kind: kindMap, u: uint64(c & 0x0f)}, nil
+	case c&0xf0 == codeFixArray:
+		return head{kind: kindArray, u: uint64(c & 0x0f)}, nil
+	case c&0xe0 == codeFixStr:
+		return head{kind: kindStr, u: uint64(c & 0x1f)}, nil
+	}
+
+	switch c {
+	case codeNil:
+		return head{kind: kindNil}, nil
+	case codeFalse, codeTrue:
+		return head{kind: kindBool, u: uint64(c - codeFalse)}, nil
+	case codeBin8, codeBin16, codeBin32:
+		n, err := d.readUint(1 << (c - codeBin8))
+		return head{kind: kindBin, u: n}, err
+	case codeStr8, codeStr16, codeStr32:
+		n, err := d.readUint(1 << (c - codeStr8))
+		return head{kind: kindStr, u: n}, err
+	case codeArray16, codeArray32:
+		n, err := d.readUint(2 << (c - codeArray16))
+		return head{kind: kindArray, u: n}, err
+	case codeMap16, codeMap32:
+		n, err := d.readUint(2 << (c - codeMap16))
+		return head{kind: kindMap, u: n}, err
+	case codeFloat32:
+		u, err := d.readUint(4)
+		return head{kind: kindFloat32, u: u}, err
+	case codeFloat64:
+		u, err := d.readUint(8)
+		return head{kind: kindFloat64, u: u}, err
+	case codeUint8, codeUint16, codeUint32, codeUint64:
+		u, err := d.readUint(1 << (c - codeUint8))
+		return head{kind: kindUint, u: u}, err
+	case codeInt8, codeInt16, codeInt32, codeInt64:
+		size := 1 << (c - codeInt8)
+		u, err := d.readUint(size)
+		// Shift the value to the top and back to extend the sign.
+		i := int64(u<<(64-8*size)) >> (64 - 8*size)
+		if i >= 0 {
+			return head{kind: kindUint, u: uint64(i)}, err
+		}
+		return head{kind: kindInt, i: i}, err
+	case codeFixExt1, codeFixExt2, codeFixExt4, codeFixExt8, codeFixExt16:
+		t, err := d.readUint(1)
+		return head{kind: kindExt, u: 1 << (c - codeFixExt1), ext: int8(t)}, err
+	case codeExt8, codeExt16, codeExt32:
+		n, err := d.readUint(1 << (c - codeExt8))
+		if err != nil {
+			return head{}, err
+		}
+		t, err := d.readUint(1)
+		return head{kind: kindExt, u: n, ext: int8(t)}, err
+	}
+	return head{}, fmt.Errorf("msgpack: invalid format code 0x%x at offset %d", c, d.off-1)
+}
+
+// readUint reads a big endian unsigned integer of size bytes.
+func (d *decoder) readUint(size int) (uint64, error) {
+	b, err := d.read(uint64(size))
+	if err != nil {
+		return 0, err
+	}
+	switch size {
+	case 1:
+		return uint64(b[0]), nil
+	case 2:
+		return uint64(binary.BigEndian.Uint16(b)), nil
+	case 4:
+		return uint64(binary.BigEndian.Uint32(b)), nil
+	}
+	return binary.BigEndian.Uint64(b), nil
+}
+
+// read returns the next n bytes. The slice points into the data.
+func (d *decoder) read(n uint64) ([]byte, error) {
+	if n > uint64(len(d.data)-d.off) {
+		return nil, d.errEOF()
+	}
+	b := d.data[d.off : d.off+int(n)]
+	d.off += int(n)
+	return b, nil
+}
+
+// peekNil reads the next value and returns true if it is nil. Otherwise it reads nothing.
+func (d *decoder) peekNil() bool {
+	if d.off < len(d.data) && d.data[d.off] == codeNil {
+		d.off++
+		return true
+	}
+	return false
+}
+
+// skip skips the rest of the value with the header h.
+func (d *decoder) skip(h head) error {
+	switch h.kind {
+	case kindStr, kindBin, kindExt:
+		_, err := d.read(h.u)
+		return err
+	case kindArray, kindMap:
+		if err := d.enter(); err != nil {
+			return err
+		}
+		defer d.leave()
+		n := h.u
+		if h.kind == kindMap {
+			n *= 2
+		}
+		for ; n > 0; n-- {
+			eh, err := d.readHead()
+			if err != nil {
+				return err
+			}
+			if err := d.skip(eh); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (d *decoder) errEOF() error {
+	return fmt.Errorf("msgpack: unexpected end of data at offset %d", len(d.data))
+}
+
+// typeError returns the error for a value of the kind in h that cannot be decoded into t.
+func typeError(h head, t reflect.Type) error {
+	return fmt.Errorf("msgpack: cannot decode %s into %s", h.kind, t)
+}
+
+// decodeFunc decodes the next value into v, which is settable.
+type decodeFunc func(d *decoder, v reflect.Value) error
+
+// decoders caches the decodeFunc for each reflect.Type.
+var decoders sync.Map
+
+// decoderFor returns the decodeFunc for t.
+func decoderFor(t reflect.Type) decodeFunc {
+	if f, ok := decoders.Load(t); ok {
+		return f.(decodeFunc)
+	}
+
+	// See encoderFor.
+	var (
+		wg sync.WaitGroup
+		f  decodeFunc
+	)
+	wg.Add(1)
+	fi, loaded := decoders.LoadOrStore(t, decodeFunc(func(d *decoder, v reflect.Value) error {
+		wg.Wait()
+		return f(d, v)
+	}))
+	if loaded {
+		return fi.(decodeFunc)
+	}
+	f = newDecoder(t)
+	wg.Done()
+	decoders.Store(t, f)
+	return f
+}
+
+// newDecoder builds the decodeFunc for t.
+func newDecoder(t reflect.Type) decodeFunc {
+	if t == timeType {
+		return decodeTime
+	}
+	if m, ok := codec.IssetOf(t); ok {
+		return newIssetDecoder(m)
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return decodeBool
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return decodeInt
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return decodeUint
+	case reflect.Float32, reflect.Float64:
+		return decodeFloat
+	case reflect.String:
+		return decodeString
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return decodeBytes
+		}
+		return newSliceDecoder(t)
+	case reflect.Array:
+		return newArrayDecoder(t)
+	case reflect.Map:
+		return newMapDecoder(t)
+	case reflect.Struct:
+		return newStructDecoder(t)
+	case reflect.Pointer:
+		return newPointerDecoder(t)
+	case reflect.Interface:
+		if t.NumMethod() == 0 {
+			return decodeInterface
+		}
+	}
+	return func(d *decoder, v reflect.Value) error {
+		return fmt.Errorf("msgpack: unsupported type %s", t)
+	}
+}
+
+// readNonNil reads the header of the next value. A nil sets v to its zero value and returns ok false.
+func (d *decoder) readNonNil(v reflect.Value) (h head, ok bool, err error) {
+	h, err = d.readHead()
+	if err != nil {
+		return h, false, err
+	}
+	if h.kind == kindNil {
+		v.SetZero()
+		return h, false, nil
+	}
+	return h, true, nil
+}
+
+func decodeBool(d *decoder, v reflect.Value) error {
+	h, ok, err := d.readNonNil(v)
+	if err != nil || !ok {
+		return err
+	}
+	if h.kind != kindBool {
+		return typeError(h, v.Type())
+	}
+	v.SetBool(h.u == 1)
+	return nil
+}
+
+func decodeInt(d *decoder, v reflect.Value) error {
+	h, ok, err := d.readNonNil(v)
+	if err != nil || !ok {
+		return err
+	}
+	var i int64
+	switch h.kind {
+	case kindInt:
+		i = h.i
+	case kindUint:
+		if h.u > math.MaxInt64 {
+			return fmt.Errorf("msgpack: value %d overflows %s", h.u, v.Type())
+		}
+		i = int64(h.u)
+	default:
+		return typeError(h, v.Type())
+	}
+	if v.OverflowInt(i) {
+		return fmt.Errorf("msgpack: value %d overflows %s", i, v.Type())
+	}
+	v.SetInt(i)
+	return nil
+}
+
+func decodeUint(d *decoder, v reflect.Value) error {
+	h, ok, err := d.readNonNil(v)
+	if err != nil || !ok {
+		return err
+	}
+	switch h.kind {
+	case kindInt:
+		return fmt.Errorf("msgpack: negative value %d for %s", h.i, v.Type())
+	case kindUint:
+	default:
+		return typeError(h, v.Type())
+	}
+	if v.OverflowUint(h.u) {
+		return fmt.Errorf("msgpack: value %d overflows %s", h.u, v.Type())
+	}
+	v.SetUint(h.u)
+	return nil
+}
+
+// decodeFloat decodes a float or an int into a float32 or float64.
+func decodeFloat(d *decoder, v reflect.Value) error {
+	h, ok, err := d.readNonNil(v)
+	if err != nil || !ok {
+		return err
+	}
+	var f float64
+	switch h.kind {
+	case kindFloat32:
+		f = float64(math.Float32frombits(uint32(h.u)))
+	case kindFloat64:
+		f = math.Float64frombits(h.u)
+	case kindUint:
+		f = float64(h.u)
+	case kindInt:
+		f = float64(h.i)
+	default:
+		return typeError(h, v.Type())
+	}
+	if v.Kind() == reflect.Float32 && h.kind == kindFloat64 && v.OverflowFloat(f) {
+		return fmt.Errorf("msgpack: value %v overflows %s", f, v.Type())
+	}
+	v.SetFloat(f)
+	return nil
+}
+
+// decodeString decodes a str or bin into a string.
+func decodeString(d *decoder, v reflect.Value) error {
+	h, ok, err := d.readNonNil(v)
+	if err != nil || !ok {
+		return err
+	}
+	if h.kind != kindStr && h.kind != kindBin {
+		return typeError(h, v.Type())
+	}
+	b, err := d.read(h.u)
+	if err != nil {
+		return err
+	}
+	v.SetString(string(b))
+	return nil
+}
+
+// decodeBytes decodes a bin or str into a byte slice, which does not point into the data.
+func decodeBytes(d *decoder, v reflect.Value) error {
+	h, ok, err := d.readNonNil(v)
+	if err != nil || !ok {
+		return err
+	}
+	if h.kind != kindBin && h.kind != kindStr {
+		return typeError(h, v.Type())
+	}
+	b, err := d.read(h.u)
+	if err != nil {
+		return err
+	}
+	v.SetBytes(append(make([]byte, 0, len(b)), b...))
+	return nil
+}
+
+func decodeTime(d *decoder, v reflect.Value) error {
+	h, ok, err := d.readNonNil(v)
+	if err != nil || !ok {
+		return err
+	}
+	if h.kind != kindExt || h.ext != extTimestamp {
+		return typeError(h, v.Type())
+	}
+	t, err := d.readTime(h)
+	if err != nil {
+		return err
+	}
+	v.Set(reflect.ValueOf(t))
+	return nil
+}
+
+// readTime reads the data of the timestamp extension with the header h. The time is in UTC.
+func (d *decoder) readTime(h head) (time.Time, error) {
+	b, err := d.read(h.u)
+	if err != nil {
+		return time.Time{}, err
+	}
+	var sec, nsec int64
+	switch len(b) {
+	case 4:
+		sec = int64(binary.BigEndian.Uint32(b))
+	case 8:
+		u := binary.BigEndian.Uint64(b)
+		sec, nsec = int64(u&(1<<34-1)), int64(u>>34)
+	case 12:
+		nsec, sec = int64(binary.BigEndian.Uint32(b)), int64(binary.BigEndian.Uint64(b[4:]))
+	default:
+		return time.Time{}, fmt.Errorf("msgpack: invalid timestamp length %d", len(b))
+	}
+	if nsec >= 1e9 {
+		return time.Time{}, fmt.Errorf("msgpack: invalid timestamp nanoseconds %d", nsec)
+	}
+	return time.Unix(sec, nsec).UTC(), nil
+}
+
+func decodeInterface(d *decoder, v reflect.Value) error {
+	h, err := d.readHead()
+	if err != nil {
+		return err
+	}
+	a, err := d.decodeAny(h)
+	if err != nil {
+		return err
+	}
+	if a == nil {
+		v.SetZero()
+		return nil
+	}
+	v.Set(reflect.ValueOf(a))
+	return nil
+}
+
+// decodeAny decodes the value with the header h into the Go value used for an interface.
+func (d *decoder) decodeAny(h head) (any, error) {
+	switch h.kind {
+	case kindNil:
+		return nil, nil
+	case kindBool:
+		return h.u == 1, nil
+	case kindUint:
+		if h.u > math.MaxInt64 {
+			return h.u, nil
+		}
+		return int64(h.u), nil
+	case kindInt:
+		return h.i, nil
+	case kindFloat32:
+		return float64(math.Float32frombits(uint32(h.u))), nil
+	case kindFloat64:
+		return math.Float64frombits(h.u), nil
+	case kindStr:
+		b, err := d.read(h.u)
+		return string(b), err
+	case kindBin:
+		b, err := d.read(h.u)
+		return append([]byte{}, b...), err
+	case kindArray:
+		if err := d.enter(); err != nil {
+			return nil, err
+		}
+		defer d.leave()
+		a := make([]any, 0, min(h.u, uint64(len(d.data)-d.off)))
+		for i := uint64(0); i < h.u; i++ {
+			eh, err := d.readHead()
+			if err != nil {
+				return nil, err
+			}
+			e, err := d.decodeAny(eh)
+			if err != nil {
+				return nil, err
+			}
+			a = append(a, e)
+		}
+		return a, nil
+	case kindMap:
+		if err := d.enter(); err != nil {
+			return nil, err
+		}
+		defer d.leave()
+		m := make(map[string]any, min(h.u, uint64(len(d.data)-d.off)))
+		for i := uint64(0); i < h.u; i++ {
+			kh, err := d.readHead()
+			if err != nil {
+				return nil, err
+			}
+			if kh.kind != kindStr {
+				return nil, fmt.Errorf("msgpack: cannot decode a map with %s keys into an interface", kh.kind)
+			}
+			k, err := d.read(kh.u)
+			if err != nil {
+				return nil, err
+			}
+			eh, err := d.readHead()
+			if err != nil {
+				return nil, err
+			}
+			if m[string(k)], err = d.decodeAny(eh); err != nil {
+				return nil, err
+			}
+		}
+		return m, nil
+	case kindExt:
+		if h.ext == extTimestamp {
+			return d.readTime(h)
+		}
+		return nil, fmt.Errorf("msgpack: unsupported extension type %d", h.ext)
+	}
+	return nil, fmt.Errorf("msgpack: invalid kind %s", h.kind)
+}
+
+func newPointerDecoder(t reflect.Type) decodeFunc {
+	elem := decoderFor(t.Elem())
+	return func(d *decoder, v reflect.Value) error {
+		if d.peekNil() {
+			v.SetZero()
+			return nil
+		}
+		if v.IsNil() {
+			v.Set(reflect.New(t.Elem()))
+		}
+		return elem(d, v.Elem())
+	}
+}
+
+func newSliceDecoder(t reflect.Type) decodeFunc {
+	elem := decoderFor(t.Elem())
+	return func(d *decoder, v reflect.Value) error {
+		h, ok, err := d.readNonNil(v)
+		if err != nil || !ok {
+			return err
+		}
+		if h.kind != kindArray {
+			return typeError(h, t)
+		}
+		if err := d.enter(); err != nil {
+			return err
+		}
+		defer d.leave()
+		// Each element is at least one byte, so a corrupt length cannot allocate more than the data.
+		if h.u > uint64(len(d.data)-d.off) {
+			return d.errEOF()
+		}
+		s := reflect.MakeSlice(t, int(h.u), int(h.u))
+		for i := 0; i < s.Len(); i++ {
+			if err := elem(d, s.Index(i)); err != nil {
+				return err
+			}
+		}
+		v.Set(s)
+		return nil
+	}
+}
+
+func newArrayDecoder(t reflect.Type) decodeFunc {
+	elem := decoderFor(t.Elem())
+	return func(d *decoder, v reflect.Value) error {
+		h, ok, err := d.readNonNil(v)
+		if err != nil || !ok {
+			return err
+		}
+		if h.kind != kindArray || h.u != uint64(t.Len()) {
+			return fmt.Errorf("msgpack: cannot decode %s of length %d into %s", h.kind, h.u, t)
+		}
+		if err := d.enter(); err != nil {
+			return err
+		}
+		defer d.leave()
+		for i := 0; i < t.Len(); i++ {
+			if err := elem(d, v.Index(i)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func newMapDecoder(t reflect.Type) decodeFunc {
+	key, elem := decoderFor(t.Key()), decoderFor(t.Elem())
+	return func(d *decoder, v reflect.Value) error {
+		h, ok, err := d.readNonNil(v)
+		if err != nil || !ok {
+			return err
+		}
+		if h.kind != kindMap {
+			return typeError(h, t)
+		}
+		if err := d.enter(); err != nil {
+			return err
+		}
+		defer d.leave()
+		if h.u > uint64(len(d.data)-d.off) {
+			return d.errEOF()
+		}
+		if v.IsNil() {
+			v.Set(reflect.MakeMapWithSize(t, int(h.u)))
+		}
+		for i := uint64(0); i < h.u; i++ {
+			k := reflect.New(t.Key()).Elem()
+			if err := key(d, k); err != nil {
+				return err
+			}
+			e := reflect.New(t.Elem()).Elem()
+			if err := elem(d, e); err != nil {
+				return err
+			}
+			v.SetMapIndex(k, e)
+		}
+		return nil
+	}
+}
+
+func newStructDecoder(t reflect.Type) decodeFunc {
+	fields := codec.StructFields(t, "msgpack")
+	byName := make(map[string]int, len(fields))
+	decs := make([]decodeFunc, len(fields))
+	for i, f := range fields {
+		byName[f.Name] = i
+		decs[i] = decoderFor(f.Type)
+	}
+	return func(d *decoder, v reflect.Value) error {
+		h, ok, err := d.readNonNil(v)
+		if err != nil || !ok {
+			return err
+		}
+		if h.kind != kindMap {
+			return typeError(h, t)
+		}
+		if err := d.enter(); err != nil {
+			return err
+		}
+		defer d.leave()
+		for n := h.u; n > 0; n-- {
+			kh, err := d.readHead()
+			if err != nil {
+				return err
+			}
+			if kh.kind != kindStr {
+				return fmt.Errorf("msgpack: cannot decode a map with %s keys into %s", kh.kind, t)
+			}
+			name, err := d.read(kh.u)
+			if err != nil {
+				return err
+			}
+			i, ok := byName[string(name)]
+			if !ok {
+				eh, err := d.readHead()
+				if err != nil {
+					return err
+				}
+				if err := d.skip(eh); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := decs[i](d, v.Field(fields[i].Index)); err != nil {
+				return fmt.Errorf("%w (field %s.%s)", err, t, fields[i].Name)
+			}
+		}
+		return nil
+	}
+}
+
+// newIssetDecoder returns a decodeFunc that sets the value of an isset type. A nil makes it unset, or
+// null for an isset.Nullable.
+func newIssetDecoder(m codec.Isset) decodeFunc {
+	elem := newElemDecoder(m.Elem)
+	return func(d *decoder, v reflect.Value) error {
+		if d.peekNil() {
+			m.Null(v)
+			return nil
+		}
+		e := reflect.New(m.Elem).Elem()
+		if err := elem(d, e); err != nil {
+			return err
+		}
+		m.Set(v, e)
+		return nil
+	}
+}
+
+// newElemDecoder returns the decodeFunc for the value of an isset type. A struct without exported
+// fields, such as netip.Addr, is decoded from a bin with its encoding.BinaryUnmarshaler method or else
+// from a str with its encoding.TextUnmarshaler method.
+func newElemDecoder(t reflect.Type) decodeFunc {
+	switch method := codec.MethodOf(t); method {
+	case codec.MethodBinary, codec.MethodText:
+		want := kindBin
+		if method == codec.MethodText {
+			want = kindStr
+		}
+		return func(d *decoder, v reflect.Value) error {
+			h, err := d.readHead()
+			if err != nil {
+				return err
+			}
+			if h.kind != want {
+				return typeError(h, t)
+			}
+			b, err := d.read(h.u)
+			if err != nil {
+				return err
+			}
+			if err := method.Unmarshal(b, v); err != nil {
+				return fmt.Errorf("msgpack: cannot decode %s: %w", t, err)
+			}
+			return nil
+		}
+	case codec.MethodMissing:
+		return func(d *decoder, v reflect.Value) error {
+			return fmt.Errorf("msgpack: cannot decode %s: it has no exported fields and does not implement encoding.BinaryUnmarshaler or encoding.TextUnmarshaler", t)
+		}
+	}
+	return decoderFor(t)
+}
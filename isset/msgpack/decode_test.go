@@ -0,0 +1,142 @@
+package msgpack
+
+import (
+	"bytes"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDecode(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		data []byte
+		into any
+		want any
+	}{
+		{name: "int8 form into uint", data: []byte{0xd0, 5}, into: new(uint8), want: uint8(5)},
+		{name: "uint64 form into int", data: []byte{0xcf, 0, 0, 0, 0, 0, 0, 0, 7}, into: new(int), want: 7},
+		{name: "negative fixint", data: []byte{0xff}, into: new(int16), want: int16(-1)},
+		{name: "int into float", data: []byte{0xd1, 0xff, 0x7f}, into: new(float64), want: -129.0},
+		{name: "float32 into float64", data: []byte{0xca, 0x3f, 0xc0, 0, 0}, into: new(float64), want: 1.5},
+		{name: "str16", data: []byte{0xda, 0, 2, 'h', 'i'}, into: new(string), want: "hi"},
+		{name: "bin into string", data: []byte{0xc4, 1, 'a'}, into: new(string), want: "a"},
+		{name: "str into bytes", data: []byte{0xa1, 'a'}, into: new([]byte), want: []byte("a")},
+		{name: "array32", data: []byte{0xdd, 0, 0, 0, 1, 3}, into: new([]int), want: []int{3}},
+		{name: "array", data: []byte{0x92, 1, 2}, into: new([2]uint), want: [2]uint{1, 2}},
+		{name: "map16", data: []byte{0xde, 0, 1, 1, 0xc3}, into: new(map[int]bool), want: map[int]bool{1: true}},
+		{name: "nil into slice", data: []byte{0xc0}, into: &[]int{1}, want: []int(nil)},
+		{name: "nil into pointer", data: []byte{0xc0}, into: new(*int), want: (*int)(nil)},
+		{name: "pointer", data: []byte{0x2a}, into: new(*int), want: func() *int { i := 42; return &i }()},
+		{name: "timestamp 64", data: []byte{0xd7, 0xff, 0, 0, 0, 4, 0, 0, 0, 1}, into: new(time.Time), want: time.Unix(1, 1).UTC()},
+		{name: "timestamp 96", data: []byte{0xc7, 12, 0xff, 0, 0, 0, 0, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}, into: new(time.Time), want: time.Unix(-1, 0).UTC()},
+		{
+			name: "interface",
+			data: []byte{0x86, 0xa1, 'a', 0x91, 0xc3, 0xa1, 'b', 0xe0, 0xa1, 'c', 0xcf, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+				0xa1, 'd', 0xca, 0x3f, 0xc0, 0, 0, 0xa1, 'e', 0xc4, 1, 1, 0xa1, 'f', 0xd6, 0xff, 0, 0, 0, 1},
+			into: new(any),
+			want: map[string]any{
+				"a": []any{true}, "b": int64(-32), "c": uint64(math.MaxUint64), "d": 1.5, "e": []byte{1},
+				"f": time.Unix(1, 0).UTC(),
+			},
+		},
+		{
+			name: "struct skips unknown fields",
+			data: []byte{0x83, 0xa1, 'x', 0x92, 0x81, 0xa1, 'y', 0xc0, 0xd6, 0xff, 0, 0, 0, 1, 0xa1, 'A', 1, 0xa1, 'b', 0xa1, 'z'},
+			into: &struct {
+				A int
+				B string `msgpack:"b"`
+			}{},
+			want: struct {
+				A int
+				B string `msgpack:"b"`
+			}{A: 1, B: "z"},
+		},
+	}
+
+	for _, tt := range tests {
+		if err := Unmarshal(tt.data, tt.into); err != nil {
+			t.Errorf("TestDecode(%s): Unmarshal() failed: %v", tt.name, err)
+			continue
+		}
+		if got := reflect.ValueOf(tt.into).Elem().Interface(); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("TestDecode(%s): got %#v, want %#v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		data []byte
+		into any
+	}{
+		{name: "not a pointer", data: []byte{1}, into: 1},
+		{name: "empty", data: nil, into: new(int)},
+		{name: "trailing data", data: []byte{1, 2}, into: new(int)},
+		{name: "invalid code", data: []byte{0xc1}, into: new(any)},
+		{name: "short str", data: []byte{0xa3, 'a'}, into: new(string)},
+		{name: "huge array", data: []byte{0xdd, 0xff, 0xff, 0xff, 0xff}, into: new([]int)},
+		{name: "overflow", data: []byte{0xcd, 1, 0}, into: new(int8)},
+		{name: "negative into uint", data: []byte{0xff}, into: new(uint)},
+		{name: "float into int", data: []byte{0xca, 0, 0, 0, 0}, into: new(int)},
+		{name: "str into bool", data: []byte{0xa0}, into: new(bool)},
+		{name: "array length", data: []byte{0x91, 1}, into: new([2]int)},
+		{name: "int map key into interface", data: []byte{0x81, 1, 1}, into: new(any)},
+		{name: "unknown extension", data: []byte{0xd4, 1, 0}, into: new(any)},
+		{name: "bad timestamp", data: []byte{0xd5, 0xff, 0, 0}, into: new(time.Time)},
+		{name: "struct field", data: []byte{0x81, 0xa1, 'A', 0xa0}, into: &struct{ A int }{}},
+	}
+
+	for _, tt := range tests {
+		if err := Unmarshal(tt.data, tt.into); err == nil {
+			t.Errorf("TestDecodeErrors(%s): Unmarshal() succeeded, want error", tt.name)
+		}
+	}
+}
+
+// nested is a recursive type for TestDecodeDepth.
+type nested []nested
+
+func TestDecodeDepth(t *testing.T) {
+	t.Parallel()
+
+	// arrays returns n nested arrays of one element around a nil.
+	arrays := func(n int) []byte {
+		return append(bytes.Repeat([]byte{0x91}, n), 0xc0)
+	}
+
+	tests := []struct {
+		name    string
+		data    []byte
+		into    any
+		wantErr bool
+	}{
+		{name: "max depth into interface", data: arrays(maxDepth), into: new(any)},
+		{name: "max depth into slice", data: arrays(maxDepth), into: new(nested)},
+		{name: "too deep into interface", data: arrays(maxDepth + 1), into: new(any), wantErr: true},
+		{name: "too deep into slice", data: arrays(maxDepth + 1), into: new(nested), wantErr: true},
+		{name: "far too deep", data: arrays(5_000_000), into: new(any), wantErr: true},
+		{
+			name:    "too deep in a skipped field",
+			data:    append([]byte{0x81, 0xa1, 'B'}, arrays(maxDepth+1)...),
+			into:    &struct{ A int }{},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		err := Unmarshal(tt.data, tt.into)
+		switch {
+		case err == nil && tt.wantErr:
+			t.Errorf("TestDecodeDepth(%s): Unmarshal() succeeded, want error", tt.name)
+		case err != nil && !tt.wantErr:
+			t.Errorf("TestDecodeDepth(%s): Unmarshal() failed: %v", tt.name, err)
+		}
+	}
+}
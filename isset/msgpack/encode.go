@@ -0,0 +1,327 @@
+package msgpack
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/gostdlib/types/isset/internal/codec"
+)
+
+// encodeFunc appends the encoding of v to b.
+type encodeFunc func(b []byte, v reflect.Value) ([]byte, error)
+
+// encoders caches the encodeFunc for each reflect.Type.
+var encoders sync.Map
+
+var timeType = reflect.TypeFor[time.Time]()
+
+// encoderFor returns the encodeFunc for t.
+func encoderFor(t reflect.Type) encodeFunc {
+	if f, ok := encoders.Load(t); ok {
+		return f.(encodeFunc)
+	}
+
+	// Store a func that waits for the real one, so recursive types find it instead of building
+	// it again, as encoding/json does.
+	var (
+		wg sync.WaitGroup
+		f  encodeFunc
+	)
+	wg.Add(1)
+	fi, loaded := encoders.LoadOrStore(t, encodeFunc(func(b []byte, v reflect.Value) ([]byte, error) {
+		wg.Wait()
+		return f(b, v)
+	}))
+	if loaded {
+		return fi.(encodeFunc)
+	}
+	f = newEncoder(t)
+	wg.Done()
+	encoders.Store(t, f)
+	return f
+}
+
+// newEncoder builds the encodeFunc for t.
+func newEncoder(t reflect.Type) encodeFunc {
+	if t == timeType {
+		return encodeTime
+	}
+	if m, ok := codec.IssetOf(t); ok {
+		return newIssetEncoder(m)
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return encodeBool
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return encodeInt
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return encodeUint
+	case reflect.Float32:
+		return encodeFloat32
+	case reflect.Float64:
+		return encodeFloat64
+	case reflect.String:
+		return encodeString
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return encodeBytes
+		}
+		return newArrayEncoder(t)
+	case reflect.Array:
+		return newArrayEncoder(t)
+	case reflect.Map:
+		return newMapEncoder(t)
+	case reflect.Struct:
+		return newStructEncoder(t)
+	case reflect.Pointer:
+		return newPointerEncoder(t)
+	case reflect.Interface:
+		return encodeInterface
+	}
+	return func(b []byte, v reflect.Value) ([]byte, error) {
+		return b, fmt.Errorf("msgpack: unsupported type %s", t)
+	}
+}
+
+func encodeBool(b []byte, v reflect.Value) ([]byte, error) {
+	if v.Bool() {
+		return append(b, codeTrue), nil
+	}
+	return append(b, codeFalse), nil
+}
+
+func encodeInt(b []byte, v reflect.Value) ([]byte, error) {
+	return appendInt(b, v.Int()), nil
+}
+
+func encodeUint(b []byte, v reflect.Value) ([]byte, error) {
+	return appendUint(b, v.Uint()), nil
+}
+
+func encodeFloat32(b []byte, v reflect.Value) ([]byte, error) {
+	return binary.BigEndian.AppendUint32(append(b, codeFloat32), math.Float32bits(float32(v.Float()))), nil
+}
+
+func encodeFloat64(b []byte, v reflect.Value) ([]byte, error) {
+	return binary.BigEndian.AppendUint64(append(b, codeFloat64), math.Float64bits(v.Float())), nil
+}
+
+func encodeString(b []byte, v reflect.Value) ([]byte, error) {
+	s := v.String()
+	return append(appendLen(b, len(s), codeFixStr, 32, codeStr8, codeStr16, codeStr32), s...), nil
+}
+
+func encodeBytes(b []byte, v reflect.Value) ([]byte, error) {
+	if v.IsNil() {
+		return append(b, codeNil), nil
+	}
+	p := v.Bytes()
+	return append(appendLen(b, len(p), 0, 0, codeBin8, codeBin16, codeBin32), p...), nil
+}
+
+// encodeTime encodes a time.Time with the timestamp extension type in its shortest form.
+func encodeTime(b []byte, v reflect.Value) ([]byte, error) {
+	t := v.Interface().(time.Time)
+	sec, nsec := t.Unix(), uint64(t.Nanosecond())
+	switch {
+	case sec>>34 == 0 && nsec == 0 && sec <= math.MaxUint32:
+		return binary.BigEndian.AppendUint32(append(b, codeFixExt4, 0xff), uint32(sec)), nil
+	case sec>>34 == 0:
+		return binary.BigEndian.AppendUint64(append(b, codeFixExt8, 0xff), nsec<<34|uint64(sec)), nil
+	}
+	b = binary.BigEndian.AppendUint32(append(b, codeExt8, 12, 0xff), uint32(nsec))
+	return binary.BigEndian.AppendUint64(b, uint64(sec)), nil
+}
+
+func encodeInterface(b []byte, v reflect.Value) ([]byte, error) {
+	if v.IsNil() {
+		return append(b, codeNil), nil
+	}
+	e := v.Elem()
+	return encoderFor(e.Type())(b, e)
+}
+
+func newPointerEncoder(t reflect.Type) encodeFunc {
+	elem := encoderFor(t.Elem())
+	return func(b []byte, v reflect.Value) ([]byte, error) {
+		if v.IsNil() {
+			return append(b, codeNil), nil
+		}
+		return elem(b, v.Elem())
+	}
+}
+
+func newArrayEncoder(t reflect.Type) encodeFunc {
+	elem := encoderFor(t.Elem())
+	return func(b []byte, v reflect.Value) ([]byte, error) {
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return append(b, codeNil), nil
+		}
+		n := v.Len()
+		b = appendLen(b, n, codeFixArray, 16, 0, codeArray16, codeArray32)
+		var err error
+		for i := 0; i < n; i++ {
+			if b, err = elem(b, v.Index(i)); err != nil {
+				return b, err
+			}
+		}
+		return b, nil
+	}
+}
+
+func newMapEncoder(t reflect.Type) encodeFunc {
+	key, elem := encoderFor(t.Key()), encoderFor(t.Elem())
+	return func(b []byte, v reflect.Value) ([]byte, error) {
+		if v.IsNil() {
+			return append(b, codeNil), nil
+		}
+		b = appendLen(b, v.Len(), codeFixMap, 16, 0, codeMap16, codeMap32)
+		var err error
+		for it := v.MapRange(); it.Next(); {
+			if b, err = key(b, it.Key()); err != nil {
+				return b, err
+			}
+			if b, err = elem(b, it.Value()); err != nil {
+				return b, err
+			}
+		}
+		return b, nil
+	}
+}
+
+func newStructEncoder(t reflect.Type) encodeFunc {
+	fields := codec.StructFields(t, "msgpack")
+	encs := make([]encodeFunc, len(fields))
+	for i, f := range fields {
+		encs[i] = encoderFor(f.Type)
+	}
+	return func(b []byte, v reflect.Value) ([]byte, error) {
+		n := 0
+		for _, f := range fields {
+			if !f.OmitEmpty || !v.Field(f.Index).IsZero() {
+				n++
+			}
+		}
+		b = appendLen(b, n, codeFixMap, 16, 0, codeMap16, codeMap32)
+		var err error
+		for i, f := range fields {
+			fv := v.Field(f.Index)
+			if f.OmitEmpty && fv.IsZero() {
+				continue
+			}
+			b = appendLen(b, len(f.Name), codeFixStr, 32, codeStr8, codeStr16, codeStr32)
+			b = append(b, f.Name...)
+			if b, err = encs[i](b, fv); err != nil {
+				return b, err
+			}
+		}
+		return b, nil
+	}
+}
+
+// newIssetEncoder returns an encodeFunc that encodes the value of an isset type, or nil if it is unset.
+func newIssetEncoder(m codec.Isset) encodeFunc {
+	elem := newElemEncoder(m.Elem)
+	return func(b []byte, v reflect.Value) ([]byte, error) {
+		e, ok := m.Get(v)
+		if !ok {
+			return append(b, codeNil), nil
+		}
+		return elem(b, e)
+	}
+}
+
+// newElemEncoder returns the encodeFunc for the value of an isset type. A struct without exported
+// fields, such as netip.Addr, is encoded as a bin with its encoding.BinaryMarshaler method or else as
+// a str with its encoding.TextMarshaler method. As nil means unset, a nil slice or map is encoded as an
+// empty bin, array or map, and a nil pointer or interface returns an error.
+func newElemEncoder(t reflect.Type) encodeFunc {
+	switch method := codec.MethodOf(t); method {
+	case codec.MethodBinary, codec.MethodText:
+		return func(b []byte, v reflect.Value) ([]byte, error) {
+			p, err := method.Marshal(v)
+			if err != nil {
+				return b, fmt.Errorf("msgpack: cannot encode %s: %w", t, err)
+			}
+			if method == codec.MethodBinary {
+				return append(appendLen(b, len(p), 0, 0, codeBin8, codeBin16, codeBin32), p...), nil
+			}
+			return append(appendLen(b, len(p), codeFixStr, 32, codeStr8, codeStr16, codeStr32), p...), nil
+		}
+	case codec.MethodMissing:
+		return func(b []byte, v reflect.Value) ([]byte, error) {
+			return b, fmt.Errorf("msgpack: cannot encode %s: it has no exported fields and does not implement encoding.BinaryMarshaler or encoding.TextMarshaler", t)
+		}
+	}
+
+	enc := encoderFor(t)
+	switch t.Kind() {
+	case reflect.Slice, reflect.Map, reflect.Pointer, reflect.Interface:
+		return func(b []byte, v reflect.Value) ([]byte, error) {
+			if !v.IsNil() {
+				return enc(b, v)
+			}
+			switch {
+			case t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8:
+				return append(b, codeBin8, 0), nil
+			case t.Kind() == reflect.Slice:
+				return append(b, codeFixArray), nil
+			case t.Kind() == reflect.Map:
+				return append(b, codeFixMap), nil
+			}
+			return b, fmt.Errorf("msgpack: cannot encode a set %s that is nil, as nil is the encoding of unset", t)
+		}
+	}
+	return enc
+}
+
+// appendInt appends i in the shortest form. Values that are not negative use the unsigned forms.
+func appendInt(b []byte, i int64) []byte {
+	switch {
+	case i >= 0:
+		return appendUint(b, uint64(i))
+	case i >= -32:
+		return append(b, byte(i))
+	case i >= math.MinInt8:
+		return append(b, codeInt8, byte(i))
+	case i >= math.MinInt16:
+		return binary.BigEndian.AppendUint16(append(b, codeInt16), uint16(i))
+	case i >= math.MinInt32:
+		return binary.BigEndian.AppendUint32(append(b, codeInt32), uint32(i))
+	}
+	return binary.BigEndian.AppendUint64(append(b, codeInt64), uint64(i))
+}
+
+// appendUint appends u in the shortest form.
+func appendUint(b []byte, u uint64) []byte {
+	switch {
+	case u <= 0x7f:
+		return append(b, byte(u))
+	case u <= math.MaxUint8:
+		return append(b, codeUint8, byte(u))
+	case u <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(b, codeUint16), uint16(u))
+	case u <= math.MaxUint32:
+		return binary.BigEndian.AppendUint32(append(b, codeUint32), uint32(u))
+	}
+	return binary.BigEndian.AppendUint64(append(b, codeUint64), u)
+}
+
+// appendLen appends the header of a str, bin, array or map of length n. The fix form fix is used
+// when n is less than fixMax, and the 8 bit form when code8 is not 0, as array and map have none.
+func appendLen(b []byte, n int, fix byte, fixMax int, code8, code16, code32 byte) []byte {
+	switch {
+	case n < fixMax:
+		return append(b, fix|byte(n))
+	case code8 != 0 && n <= math.MaxUint8:
+		return append(b, code8, byte(n))
+	case n <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(b, code16), uint16(n))
+	}
+	return binary.BigEndian.AppendUint32(append(b, code32), uint32(n))
+}
@@ -0,0 +1,88 @@
+package msgpack
+
+import (
+	"bytes"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestEncode(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		v    any
+		want []byte
+	}{
+		{name: "nil", v: nil, want: []byte{0xc0}},
+		{name: "true", v: true, want: []byte{0xc3}},
+		{name: "positive fixint", v: 127, want: []byte{0x7f}},
+		{name: "negative fixint", v: -32, want: []byte{0xe0}},
+		{name: "uint8", v: uint16(200), want: []byte{0xcc, 200}},
+		{name: "int8", v: int64(-33), want: []byte{0xd0, 0xdf}},
+		{name: "uint16", v: 256, want: []byte{0xcd, 1, 0}},
+		{name: "int16", v: -129, want: []byte{0xd1, 0xff, 0x7f}},
+		{name: "uint32", v: int64(math.MaxUint32), want: []byte{0xce, 0xff, 0xff, 0xff, 0xff}},
+		{name: "int32", v: int32(math.MinInt32), want: []byte{0xd2, 0x80, 0, 0, 0}},
+		{name: "uint64", v: uint64(math.MaxUint64), want: []byte{0xcf, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}},
+		{name: "int64", v: int64(math.MinInt64), want: []byte{0xd3, 0x80, 0, 0, 0, 0, 0, 0, 0}},
+		{name: "float32", v: float32(1.5), want: []byte{0xca, 0x3f, 0xc0, 0, 0}},
+		{name: "float64", v: 1.5, want: []byte{0xcb, 0x3f, 0xf8, 0, 0, 0, 0, 0, 0}},
+		{name: "fixstr", v: "hi", want: []byte{0xa2, 'h', 'i'}},
+		{name: "str8", v: string(make([]byte, 32)), want: append([]byte{0xd9, 32}, make([]byte, 32)...)},
+		{name: "bin8", v: []byte{1, 2}, want: []byte{0xc4, 2, 1, 2}},
+		{name: "nil slice", v: []int(nil), want: []byte{0xc0}},
+		{name: "fixarray", v: []int{1, -1}, want: []byte{0x92, 1, 0xff}},
+		{name: "array16", v: make([]bool, 16), want: append([]byte{0xdc, 0, 16}, bytes.Repeat([]byte{0xc2}, 16)...)},
+		{name: "fixmap", v: map[string]int{"a": 1}, want: []byte{0x81, 0xa1, 'a', 1}},
+		{name: "pointer", v: new(int), want: []byte{0}},
+		{name: "nil pointer", v: (*int)(nil), want: []byte{0xc0}},
+		{name: "interface", v: []any{"a", nil}, want: []byte{0x92, 0xa1, 'a', 0xc0}},
+		{name: "duration", v: time.Second, want: []byte{0xce, 0x3b, 0x9a, 0xca, 0}},
+		{name: "timestamp 32", v: time.Unix(1, 0), want: []byte{0xd6, 0xff, 0, 0, 0, 1}},
+		{name: "timestamp 64", v: time.Unix(1, 1), want: []byte{0xd7, 0xff, 0, 0, 0, 4, 0, 0, 0, 1}},
+		{name: "timestamp 96", v: time.Unix(-1, 0), want: []byte{0xc7, 12, 0xff, 0, 0, 0, 0, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}},
+		{
+			name: "struct",
+			v: struct {
+				A       int
+				B       string `msgpack:"b"`
+				C       int    `msgpack:",omitempty"`
+				D       int    `msgpack:"-"`
+				private int
+			}{A: 1, B: "x"},
+			want: []byte{0x82, 0xa1, 'A', 1, 0xa1, 'b', 0xa1, 'x'},
+		},
+	}
+
+	for _, tt := range tests {
+		got, err := Marshal(tt.v)
+		if err != nil {
+			t.Errorf("TestEncode(%s): Marshal() failed: %v", tt.name, err)
+			continue
+		}
+		if !bytes.Equal(got, tt.want) {
+			t.Errorf("TestEncode(%s): Marshal() = % x, want % x", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestEncodeErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		v    any
+	}{
+		{name: "chan", v: make(chan int)},
+		{name: "func in struct", v: struct{ F func() }{}},
+		{name: "complex in map", v: map[string]complex64{"a": 1}},
+	}
+
+	for _, tt := range tests {
+		if _, err := Marshal(tt.v); err == nil {
+			t.Errorf("TestEncodeErrors(%s): Marshal() succeeded, want error", tt.name)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package msgpack
+
+import (
+	"bytes"
+	"net/netip"
+	"testing"
+
+	"github.com/gostdlib/types/isset"
+	"github.com/gostdlib/types/isset/internal/codectest"
+)
+
+func TestIsset(t *testing.T) {
+	t.Parallel()
+
+	codectest.Isset(t, Marshal, Unmarshal)
+}
+
+func TestIssetEncoding(t *testing.T) {
+	t.Parallel()
+
+	type small struct {
+		A isset.Int                 `msgpack:"a"`
+		B isset.Int                 `msgpack:"b,omitempty"`
+		C isset.Nullable[bool]      `msgpack:"c,omitempty"`
+		D isset.Nullable[bool]      `msgpack:"d,omitempty"`
+		E isset.Of[map[string]bool] `msgpack:"e,omitempty"`
+	}
+
+	in := small{C: isset.Nullable[bool]{}.SetNull(), E: isset.Of[map[string]bool]{}.Set(nil)}
+	// A set nil map is encoded as an empty map, as nil means unset.
+	want := []byte{0x83, 0xa1, 'a', 0xc0, 0xa1, 'c', 0xc0, 0xa1, 'e', 0x80}
+	got, err := Marshal(in)
+	if err != nil || !bytes.Equal(got, want) {
+		t.Errorf("TestIssetEncoding: Marshal() = % x, %v, want % x", got, err, want)
+	}
+
+	// nil makes a value unset, or null for a Nullable, and a missing field leaves it unset.
+	out := small{
+		A: isset.Int{}.Set(1),
+		C: isset.Nullable[bool]{}.Set(true),
+	}
+	if err := Unmarshal([]byte{0x82, 0xa1, 'a', 0xc0, 0xa1, 'c', 0xc0}, &out); err != nil {
+		t.Fatalf("TestIssetEncoding: Unmarshal() failed: %v", err)
+	}
+	if out.A.IsSet() || !out.C.IsNull() || out.B.IsSet() || !out.D.IsAbsent() {
+		t.Errorf("TestIssetEncoding: Unmarshal() = %+v, want a unset, c null and b and d untouched", out)
+	}
+}
+
+func TestIssetErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		data []byte
+		into any
+	}{
+		{name: "str into Int", data: []byte{0xa1, '1'}, into: new(isset.Int)},
+		{name: "overflow", data: []byte{0xcd, 1, 0}, into: new(isset.Uint8)},
+		{name: "int into Time", data: []byte{1}, into: new(isset.Time)},
+		{name: "bool into Of", data: []byte{0xc3}, into: new(isset.Of[struct{ X int }])},
+		{name: "str into Of[netip.Addr]", data: []byte{0xa1, '1'}, into: new(isset.Of[netip.Addr])},
+		{name: "invalid netip.Addr", data: []byte{0xc4, 1, 1}, into: new(isset.Of[netip.Addr])},
+		{name: "Of without methods", data: []byte{0x80}, into: new(isset.Of[struct{ n int }])},
+	}
+
+	for _, tt := range tests {
+		if err := Unmarshal(tt.data, tt.into); err == nil {
+			t.Errorf("TestIssetErrors(%s): Unmarshal() succeeded, want error", tt.name)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+/*
+Package msgpack encodes and decodes MessagePack (https://msgpack.org) for structs that contain isset types.
+
+It is a small codec without dependencies outside of the standard library that covers what is needed to
+send structs of isset fields over MessagePack. A set value is encoded as its value and an unset value as
+nil, or is omitted from the struct with the `omitempty` struct tag option. Decoding nil makes the value
+unset, or null for an isset.Nullable, and a missing field leaves the value unset:
+
+	type Config struct {
+		Host    isset.String `msgpack:"host"`
+		Port    isset.Uint16 `msgpack:"port,omitempty"` // Omitted when unset.
+		Timeout isset.Duration                           // nil when unset, the key is "Timeout".
+	}
+
+	b, err := msgpack.Marshal(cfg)
+	...
+	err = msgpack.Unmarshal(b, &cfg)
+
+Go values are mapped to MessagePack as follows:
+
+	bool                    bool
+	int*, uint*             int, in the shortest form that holds the value
+	float32, float64        float 32 and float 64
+	string                  str
+	[]byte                  bin
+	slices and arrays       array
+	maps                    map
+	structs                 map with the field names, or the name in the msgpack struct tag, as keys
+	pointers, interfaces    the value they point to or hold, or nil
+	time.Time               the timestamp extension type
+	time.Duration           int, in nanoseconds
+	isset types             the value V returns, or nil when unset
+	isset.Of[netip.Addr]    bin from MarshalBinary, or else str from MarshalText, as for every isset value
+	                        that is a struct without exported fields, and an error if it has neither
+
+When decoding into an interface, the values are nil, bool, int64, uint64 (only for values above
+math.MaxInt64), float64, string, []byte, []any, map[string]any and time.Time.
+
+Struct fields are matched by their exact name and unknown fields are skipped. Unexported fields and fields
+tagged with `msgpack:"-"` are ignored. The `omitempty` option omits a field when it has its zero value as
+reported by reflect.Value.IsZero, which for the isset types means unset. An isset.Nullable that is null is
+encoded as nil even with `omitempty`, while an absent one is omitted. As nil means unset, a set value that
+is a nil slice or map is encoded as an empty bin, array or map, and one that is a nil pointer or interface
+returns an error.
+*/
+package msgpack
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Marshal returns the MessagePack encoding of v.
+func Marshal(v any) ([]byte, error) {
+	if v == nil {
+		return []byte{codeNil}, nil
+	}
+	rv := reflect.ValueOf(v)
+	return encoderFor(rv.Type())(nil, rv)
+}
+
+// Unmarshal decodes the MessagePack data into the value v points to. The data must hold a single value,
+// in which arrays and maps are nested at most 10000 deep.
+func Unmarshal(data []byte, v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return fmt.Errorf("msgpack: Unmarshal(non-pointer or nil %T)", v)
+	}
+	d := &decoder{data: data}
+	if err := decoderFor(rv.Type().Elem())(d, rv.Elem()); err != nil {
+		return err
+	}
+	if d.off != len(d.data) {
+		return fmt.Errorf("msgpack: %d bytes after the value", len(d.data)-d.off)
+	}
+	return nil
+}
+
+// MessagePack format codes. See https://github.com/msgpack/msgpack/blob/master/spec.md.
+const (
+	codeNil      = 0xc0
+	codeFalse    = 0xc2
+	codeTrue     = 0xc3
+	codeBin8     = 0xc4
+	codeBin16    = 0xc5
+	codeBin32    = 0xc6
+	codeExt8     = 0xc7
+	codeExt16    = 0xc8
+	codeExt32    = 0xc9
+	codeFloat32  = 0xca
+	codeFloat64  = 0xcb
+	codeUint8    = 0xcc
+	codeUint16   = 0xcd
+	codeUint32   = 0xce
+	codeUint64   = 0xcf
+	codeInt8     = 0xd0
+	codeInt16    = 0xd1
+	codeInt32    = 0xd2
+	codeInt64    = 0xd3
+	codeFixExt1  = 0xd4
+	codeFixExt2  = 0xd5
+	codeFixExt4  = 0xd6
+	codeFixExt8  = 0xd7
+	codeFixExt16 = 0xd8
+	codeStr8     = 0xd9
+	codeStr16    = 0xda
+	codeStr32    = 0xdb
+	codeArray16  = 0xdc
+	codeArray32  = 0xdd
+	codeMap16    = 0xde
+	codeMap32    = 0xdf
+
+	codeFixMap   = 0x80
+	codeFixArray = 0x90
+	codeFixStr   = 0xa0
+
+	// extTimestamp is the extension type of timestamps.
+	extTimestamp = -1
+)
@@ -0,0 +1,56 @@
+package msgpack
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/gostdlib/types/isset"
+)
+
+type tree struct {
+	Value    isset.Int
+	Children []tree `msgpack:",omitempty"`
+}
+
+func TestRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		in   any
+	}{
+		{name: "recursive type", in: tree{Value: isset.Int{}.Set(1), Children: []tree{{}, {Value: isset.Int{}.Set(2)}}}},
+		{name: "map of isset", in: map[string]isset.Float64{"a": isset.Float64{}.Set(1.5), "b": {}}},
+		{name: "slice of pointers", in: []*isset.String{nil, ptr(isset.String{}.Set("a"))}},
+		{name: "long string", in: string(make([]byte, 70000))},
+	}
+
+	for _, tt := range tests {
+		b, err := Marshal(tt.in)
+		if err != nil {
+			t.Errorf("TestRoundTrip(%s): Marshal() failed: %v", tt.name, err)
+			continue
+		}
+		got := reflect.New(reflect.TypeOf(tt.in))
+		if err := Unmarshal(b, got.Interface()); err != nil {
+			t.Errorf("TestRoundTrip(%s): Unmarshal() failed: %v", tt.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(got.Elem().Interface(), tt.in) {
+			t.Errorf("TestRoundTrip(%s): got %+v, want %+v", tt.name, got.Elem().Interface(), tt.in)
+		}
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func TestUnmarshalNilPointer(t *testing.T) {
+	t.Parallel()
+
+	var p *int
+	if err := Unmarshal([]byte{1}, p); err == nil {
+		t.Errorf("TestUnmarshalNilPointer: Unmarshal(nil pointer) succeeded, want error")
+	}
+}
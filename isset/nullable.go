@@ -0,0 +1,163 @@
+package isset
+
+import (
+	"github.com/go-json-experiment/json"
+	"github.com/go-json-experiment/json/jsontext"
+)
+
+// nullState is the state of a Nullable value. The zero value is nullAbsent.
+type nullState uint8
+
+const (
+	nullAbsent nullState = iota
+	nullNull
+	nullSet
+)
+
+// Nullable is a tri-state type representing a value of type T that is either absent, explicitly null
+// or set. This is useful for PATCH style APIs where an omitted field means "leave unchanged" and a
+// null field means "clear the value", a distinction the other types in this package do not make.
+//
+// On decode, a missing field leaves the value absent (the zero value), a JSON null makes it null and
+// any other value makes it set. On encode, a set value encodes as the value and a null value encodes as
+// null. An absent value also encodes as null, as a JSON marshaller must write a value, so tag the field
+// with `omitzero` to omit it from the output. IsZero reports if the value is absent for this purpose.
+//
+// Like Of, encoding and decoding of a set value is delegated to the JSON methods of T if it has them.
+type Nullable[T any] struct {
+	v     T
+	state nullState
+}
+
+// V returns the value. It is the zero value of T unless the value is set.
+func (i Nullable[T]) V() T {
+	return i.v
+}
+
+// IsSet returns if the value was set to a non-null value.
+func (i Nullable[T]) IsSet() bool {
+	return i.state == nullSet
+}
+
+// IsNull returns if the value was explicitly set to null.
+func (i Nullable[T]) IsNull() bool {
+	return i.state == nullNull
+}
+
+// IsAbsent returns if the value was neither set nor set to null.
+func (i Nullable[T]) IsAbsent() bool {
+	return i.state == nullAbsent
+}
+
+// IsZero reports if the value is absent.
+func (i Nullable[T]) IsZero() bool {
+	return i.state == nullAbsent
+}
+
+// Set sets the value and marks it as set.
+func (i Nullable[T]) Set(val T) Nullable[T] {
+	i.v = val
+	i.state = nullSet
+	return i
+}
+
+// SetNull sets the value to its zero value and marks it as null.
+func (i Nullable[T]) SetNull() Nullable[T] {
+	var zero T
+	i.v = zero
+	i.state = nullNull
+	return i
+}
+
+// Unset retuns the value to its zero value and marks it as absent.
+func (i Nullable[T]) Unset() Nullable[T] {
+	var zero T
+	i.v = zero
+	i.state = nullAbsent
+	return i
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (i Nullable[T]) MarshalJSON() ([]byte, error) {
+	if i.state != nullSet {
+		return []byte("null"), nil
+	}
+	return marshalJSONOf(&i.v)
+}
+
+// AppendJSON appends the JSON encoding returned by MarshalJSON to dst. A set value is first encoded
+// into a new slice, like MarshalJSON does, so unlike the other types this allocates.
+func (i Nullable[T]) AppendJSON(dst []byte) ([]byte, error) {
+	if i.state != nullSet {
+		return append(dst, "null"...), nil
+	}
+	b, err := marshalJSONOf(&i.v)
+	if err != nil {
+		return dst, err
+	}
+	return append(dst, b...), nil
+}
+
+// MarshalJSONV2 implements the json.MarshalerV2 interface.
+func (i Nullable[T]) MarshalJSONV2(enc *jsontext.Encoder, opts json.Options) error {
+	if i.state != nullSet {
+		return enc.WriteToken(jsontext.Null)
+	}
+	return marshalJSONV2Of(enc, &i.v, opts)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (i *Nullable[T]) UnmarshalJSON(data []byte) error {
+	if bytesToStr(data) == "null" {
+		*i = i.SetNull()
+		return nil
+	}
+
+	var t T
+	if err := unmarshalJSONOf(data, &t); err != nil {
+		return err
+	}
+	i.v = t
+	i.state = nullSet
+	return nil
+}
+
+// UnmarshalJSONV2 implements the json.UnmarshalerV2 interface.
+func (v *Nullable[T]) UnmarshalJSONV2(dec *jsontext.Decoder, opts json.Options) error {
+	if dec.PeekKind() == 'n' {
+		if _, err := dec.ReadToken(); err != nil {
+			return err
+		}
+		*v = v.SetNull()
+		return nil
+	}
+
+	var t T
+	if err := unmarshalJSONV2Of(dec, &t, opts); err != nil {
+		return err
+	}
+	v.v = t
+	v.state = nullSet
+	return nil
+}
+
+// MarshalText implements the encoding.TextMarshaler interface by delegating to T, which must implement
+// encoding.TextMarshaler. A value that is not set marshals to empty text.
+func (i Nullable[T]) MarshalText() ([]byte, error) {
+	if i.state != nullSet {
+		return []byte{}, nil
+	}
+	return marshalTextOf(&i.v)
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface by delegating to T, which must
+// implement encoding.TextUnmarshaler. It marks the value as set on success.
+func (i *Nullable[T]) UnmarshalText(text []byte) error {
+	var t T
+	if err := unmarshalTextOf(text, &t); err != nil {
+		return err
+	}
+	i.v = t
+	i.state = nullSet
+	return nil
+}
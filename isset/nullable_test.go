@@ -0,0 +1,164 @@
+package isset
+
+import (
+	stdjson "encoding/json"
+	"testing"
+
+	"github.com/go-json-experiment/json"
+)
+
+func TestNullable(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		operation    func() Nullable[int]
+		wantInt      int
+		wantIsSet    bool
+		wantIsNull   bool
+		wantIsAbsent bool
+	}{
+		{
+			name: "Set Nullable",
+			operation: func() Nullable[int] {
+				var v Nullable[int]
+				return v.Set(42)
+			},
+			wantInt:   42,
+			wantIsSet: true,
+		},
+		{
+			name: "SetNull Nullable",
+			operation: func() Nullable[int] {
+				var v Nullable[int]
+				v = v.Set(42)
+				return v.SetNull()
+			},
+			wantInt:    0,
+			wantIsNull: true,
+		},
+		{
+			name: "Unset Nullable",
+			operation: func() Nullable[int] {
+				var v Nullable[int]
+				v = v.Set(42)
+				return v.Unset()
+			},
+			wantInt:      0,
+			wantIsAbsent: true,
+		},
+		{
+			name: "Default Nullable",
+			operation: func() Nullable[int] {
+				return Nullable[int]{}
+			},
+			wantInt:      0,
+			wantIsAbsent: true,
+		},
+		{
+			name: "Set the zero Nullable",
+			operation: func() Nullable[int] {
+				var v Nullable[int]
+				return v.Set(0)
+			},
+			wantInt:   0,
+			wantIsSet: true,
+		},
+	}
+
+	for _, tt := range tests {
+		v := tt.operation()
+		if got := v.V(); got != tt.wantInt {
+			t.Errorf("TestNullable(%s): V() = %v, want %v", tt.name, got, tt.wantInt)
+		}
+		if got := v.IsSet(); got != tt.wantIsSet {
+			t.Errorf("TestNullable(%s): IsSet() = %v, want %v", tt.name, got, tt.wantIsSet)
+		}
+		if got := v.IsNull(); got != tt.wantIsNull {
+			t.Errorf("TestNullable(%s): IsNull() = %v, want %v", tt.name, got, tt.wantIsNull)
+		}
+		if got := v.IsAbsent(); got != tt.wantIsAbsent {
+			t.Errorf("TestNullable(%s): IsAbsent() = %v, want %v", tt.name, got, tt.wantIsAbsent)
+		}
+		if got := v.IsZero(); got != tt.wantIsAbsent {
+			t.Errorf("TestNullable(%s): IsZero() = %v, want %v", tt.name, got, tt.wantIsAbsent)
+		}
+	}
+}
+
+func TestNullableMarshalling(t *testing.T) {
+	t.Parallel()
+
+	type patch struct {
+		Name  Nullable[string] `json:",omitzero"`
+		Count Nullable[int]    `json:",omitzero"`
+		Color Nullable[color]  `json:",omitzero"`
+	}
+
+	tests := []struct {
+		name      string
+		jsonInput string
+		want      patch
+	}{
+		{
+			name:      "All absent",
+			jsonInput: `{}`,
+			want:      patch{},
+		},
+		{
+			name:      "All null",
+			jsonInput: `{"Name":null,"Count":null,"Color":null}`,
+			want: patch{
+				Name:  Nullable[string]{}.SetNull(),
+				Count: Nullable[int]{}.SetNull(),
+				Color: Nullable[color]{}.SetNull(),
+			},
+		},
+		{
+			name:      "All set",
+			jsonInput: `{"Name":"","Count":0,"Color":"red"}`,
+			want: patch{
+				Name:  Nullable[string]{}.Set(""),
+				Count: Nullable[int]{}.Set(0),
+				Color: Nullable[color]{}.Set(red),
+			},
+		},
+		{
+			name:      "Mixed",
+			jsonInput: `{"Name":"bob","Color":null}`,
+			want: patch{
+				Name:  Nullable[string]{}.Set("bob"),
+				Color: Nullable[color]{}.SetNull(),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		// Test v1 decoding
+		var v1 patch
+		if err := stdjson.Unmarshal([]byte(tt.jsonInput), &v1); err != nil {
+			t.Fatalf("TestNullableMarshalling(%s)(v1) failed: %v", tt.name, err)
+		}
+		if v1 != tt.want {
+			t.Errorf("TestNullableMarshalling(%s)(v1): got %+v, want %+v", tt.name, v1, tt.want)
+		}
+
+		// Test v2 decoding
+		var v2 patch
+		if err := json.Unmarshal([]byte(tt.jsonInput), &v2); err != nil {
+			t.Fatalf("TestNullableMarshalling(%s)(v2) failed: %v", tt.name, err)
+		}
+		if v2 != tt.want {
+			t.Errorf("TestNullableMarshalling(%s)(v2): got %+v, want %+v", tt.name, v2, tt.want)
+		}
+
+		// Test v2 encoding round trips the input.
+		out, err := json.Marshal(tt.want)
+		if err != nil {
+			t.Fatalf("TestNullableMarshalling(%s)(marshal) failed: %v", tt.name, err)
+		}
+		if string(out) != tt.jsonInput {
+			t.Errorf("TestNullableMarshalling(%s)(marshal): got %s, want %s", tt.name, out, tt.jsonInput)
+		}
+	}
+}
@@ -0,0 +1,212 @@
+package isset
+
+import (
+	"encoding"
+	"fmt"
+
+	"github.com/go-json-experiment/json"
+	"github.com/go-json-experiment/json/jsontext"
+)
+
+// Of is a type representing a value of any type T that can be set or unset. It is intended for
+// user defined types such as structs, enumerations or netip.Addr that do not have a concrete type in
+// this package. Prefer the concrete types (Int, String, ...) for basic types, as they have faster
+// encoding paths.
+//
+// JSON encoding and decoding is delegated to the json.MarshalerV1/V2 and json.UnmarshalerV1/V2
+// methods of T if it has them. Calling those methods directly avoids the reflection done by the json
+// package, which is only used as the fallback for types without methods.
+type Of[T any] struct {
+	v     T
+	isSet bool
+}
+
+// V returns the value.
+func (i Of[T]) V() T {
+	return i.v
+}
+
+// IsSet returns if the value was set.
+func (i Of[T]) IsSet() bool {
+	return i.isSet
+}
+
+// IsZero reports if the value is unset.
+func (i Of[T]) IsZero() bool {
+	return !i.isSet
+}
+
+// Set sets the value and marks it as set.
+func (i Of[T]) Set(val T) Of[T] {
+	i.v = val
+	i.isSet = true
+	return i
+}
+
+// Unset retuns the value to its zero value and marks it as unset.
+func (i Of[T]) Unset() Of[T] {
+	var zero T
+	i.v = zero
+	i.isSet = false
+	return i
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (i Of[T]) MarshalJSON() ([]byte, error) {
+	if getDefaults().writeNull(i.isSet) {
+		return []byte("null"), nil
+	}
+	return marshalJSONOf(&i.v)
+}
+
+// AppendJSON appends the JSON encoding returned by MarshalJSON to dst. A set value is first encoded
+// into a new slice, like MarshalJSON does, so unlike the other types this allocates.
+func (i Of[T]) AppendJSON(dst []byte) ([]byte, error) {
+	if getDefaults().writeNull(i.isSet) {
+		return append(dst, "null"...), nil
+	}
+	b, err := marshalJSONOf(&i.v)
+	if err != nil {
+		return dst, err
+	}
+	return append(dst, b...), nil
+}
+
+// MarshalJSONV2 implements the json.MarshalerV2 interface.
+func (i Of[T]) MarshalJSONV2(enc *jsontext.Encoder, opts json.Options) error {
+	return i.marshalJSONV2(enc, opts, getDefaults())
+}
+
+func (i Of[T]) marshalJSONV2(enc *jsontext.Encoder, opts json.Options, o *options) error {
+	if o.writeNull(i.isSet) {
+		return enc.WriteToken(jsontext.Null)
+	}
+	return marshalJSONV2Of(enc, &i.v, opts)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (i *Of[T]) UnmarshalJSON(data []byte) error {
+	if bytesToStr(data) == "null" {
+		var zero T
+		i.isSet = false
+		i.v = zero
+		return nil
+	}
+
+	var t T
+	if err := unmarshalJSONOf(data, &t); err != nil {
+		return err
+	}
+	i.v = t
+	i.isSet = true
+	return nil
+}
+
+// UnmarshalJSONV2 implements the json.UnmarshalerV2 interface.
+func (v *Of[T]) UnmarshalJSONV2(dec *jsontext.Decoder, opts json.Options) error {
+	if dec.PeekKind() == 'n' {
+		if _, err := dec.ReadToken(); err != nil {
+			return err
+		}
+		var zero T
+		v.isSet = false
+		v.v = zero
+		return nil
+	}
+
+	var t T
+	if err := unmarshalJSONV2Of(dec, &t, opts); err != nil {
+		return err
+	}
+	v.v = t
+	v.isSet = true
+	return nil
+}
+
+// marshalJSONOf encodes *p with the v1 JSON method of T, falling back to json.Marshal.
+// The method set of *T includes the methods declared on T, so only p needs to be checked.
+func marshalJSONOf[T any](p *T) ([]byte, error) {
+	if m, ok := any(p).(json.MarshalerV1); ok {
+		return m.MarshalJSON()
+	}
+	return json.Marshal(p)
+}
+
+// marshalJSONV2Of encodes *p with the JSON methods of T, preferring the v2 method, and falls
+// back to json.MarshalEncode.
+func marshalJSONV2Of[T any](enc *jsontext.Encoder, p *T, opts json.Options) error {
+	switch m := any(p).(type) {
+	case json.MarshalerV2:
+		return m.MarshalJSONV2(enc, opts)
+	case json.MarshalerV1:
+		b, err := m.MarshalJSON()
+		if err != nil {
+			return err
+		}
+		return enc.WriteValue(b)
+	}
+	return json.MarshalEncode(enc, p, opts)
+}
+
+// unmarshalJSONOf decodes data into p with the v1 JSON method of T, falling back to json.Unmarshal.
+func unmarshalJSONOf[T any](data []byte, p *T) error {
+	if u, ok := any(p).(json.UnmarshalerV1); ok {
+		return u.UnmarshalJSON(data)
+	}
+	return json.Unmarshal(data, p)
+}
+
+// unmarshalJSONV2Of decodes the next value in dec into p with the JSON methods of T, preferring the
+// v2 method, and falls back to json.UnmarshalDecode.
+func unmarshalJSONV2Of[T any](dec *jsontext.Decoder, p *T, opts json.Options) error {
+	switch u := any(p).(type) {
+	case json.UnmarshalerV2:
+		return u.UnmarshalJSONV2(dec, opts)
+	case json.UnmarshalerV1:
+		val, err := dec.ReadValue()
+		if err != nil {
+			return err
+		}
+		return u.UnmarshalJSON(val)
+	}
+	return json.UnmarshalDecode(dec, p, opts)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface by delegating to T, which must implement
+// encoding.TextMarshaler. An unset value marshals to empty text.
+func (i Of[T]) MarshalText() ([]byte, error) {
+	if !i.isSet {
+		return []byte{}, nil
+	}
+	return marshalTextOf(&i.v)
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface by delegating to T, which must
+// implement encoding.TextUnmarshaler. It marks the value as set on success.
+func (i *Of[T]) UnmarshalText(text []byte) error {
+	var t T
+	if err := unmarshalTextOf(text, &t); err != nil {
+		return err
+	}
+	i.v = t
+	i.isSet = true
+	return nil
+}
+
+// marshalTextOf encodes *p with the encoding.TextMarshaler method of T.
+func marshalTextOf[T any](p *T) ([]byte, error) {
+	m, ok := any(p).(encoding.TextMarshaler)
+	if !ok {
+		return nil, fmt.Errorf("%T does not implement encoding.TextMarshaler", *p)
+	}
+	return m.MarshalText()
+}
+
+// unmarshalTextOf decodes text into p with the encoding.TextUnmarshaler method of T.
+func unmarshalTextOf[T any](text []byte, p *T) error {
+	u, ok := any(p).(encoding.TextUnmarshaler)
+	if !ok {
+		return fmt.Errorf("%T does not implement encoding.TextUnmarshaler", *p)
+	}
+	return u.UnmarshalText(text)
+}
@@ -0,0 +1,254 @@
+package isset
+
+import (
+	"bytes"
+	stdjson "encoding/json"
+	"errors"
+	"fmt"
+	"net/netip"
+	"testing"
+
+	"github.com/go-json-experiment/json"
+	"github.com/go-json-experiment/json/jsontext"
+)
+
+type color int
+
+const (
+	red color = iota + 1
+	green
+)
+
+// MarshalJSON implements the json.Marshaler interface.
+func (c color) MarshalJSON() ([]byte, error) {
+	switch c {
+	case red:
+		return []byte(`"red"`), nil
+	case green:
+		return []byte(`"green"`), nil
+	}
+	return nil, errors.New("unknown color")
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (c *color) UnmarshalJSON(data []byte) error {
+	switch string(data) {
+	case `"red"`:
+		*c = red
+	case `"green"`:
+		*c = green
+	default:
+		return fmt.Errorf("unknown color %s", data)
+	}
+	return nil
+}
+
+type point struct {
+	X, Y int
+}
+
+func TestOf(t *testing.T) {
+	t.Parallel()
+
+	p := point{X: 1, Y: 2}
+
+	tests := []struct {
+		name      string
+		operation func() Of[point]
+		wantPoint point
+		wantIsSet bool
+	}{
+		{
+			name: "Set Of",
+			operation: func() Of[point] {
+				var v Of[point]
+				return v.Set(p)
+			},
+			wantPoint: p,
+			wantIsSet: true,
+		},
+		{
+			name: "Unset Of",
+			operation: func() Of[point] {
+				var v Of[point]
+				v = v.Set(p)
+				return v.Unset()
+			},
+			wantPoint: point{}, // Default zero point
+			wantIsSet: false,
+		},
+		{
+			name: "Default Of",
+			operation: func() Of[point] {
+				return Of[point]{}
+			},
+			wantPoint: point{}, // Default zero point
+			wantIsSet: false,
+		},
+		{
+			name: "Set the zero Of",
+			operation: func() Of[point] {
+				var v Of[point]
+				return v.Set(point{})
+			},
+			wantPoint: point{},
+			wantIsSet: true,
+		},
+	}
+
+	for _, tt := range tests {
+		v := tt.operation()
+		if got := v.V(); got != tt.wantPoint {
+			t.Errorf("TestOf(%s): V() = %v, want %v", tt.name, got, tt.wantPoint)
+		}
+		if got := v.IsSet(); got != tt.wantIsSet {
+			t.Errorf("TestOf(%s): IsSet() = %v, want %v", tt.name, got, tt.wantIsSet)
+		}
+	}
+}
+
+func TestOfMarshalling(t *testing.T) {
+	t.Parallel()
+
+	addr := netip.MustParseAddr("192.0.2.1")
+
+	tests := []struct {
+		name       string
+		initial    interface{ MarshalJSON() ([]byte, error) }
+		wantOutput string
+	}{
+		{
+			name:       "Marshal set struct",
+			initial:    Of[point]{}.Set(point{X: 1, Y: 2}),
+			wantOutput: `{"X":1,"Y":2}`,
+		},
+		{
+			name:       "Marshal set type with JSON methods",
+			initial:    Of[color]{}.Set(green),
+			wantOutput: `"green"`,
+		},
+		{
+			name:       "Marshal set type with text methods",
+			initial:    Of[netip.Addr]{}.Set(addr),
+			wantOutput: `"192.0.2.1"`,
+		},
+		{
+			name:       "Marshal unset Of",
+			initial:    Of[point]{},
+			wantOutput: "null",
+		},
+	}
+
+	for _, tt := range tests {
+		jsonBytes, err := tt.initial.MarshalJSON()
+		if err != nil {
+			t.Fatalf("TestMarshalling(%s) failed: %v", tt.name, err)
+		}
+		if gotOutput := string(jsonBytes); gotOutput != tt.wantOutput {
+			t.Errorf("TestMarshalling(%s) = %v, want %v", tt.name, gotOutput, tt.wantOutput)
+		}
+
+		var buf bytes.Buffer
+		enc := jsontext.NewEncoder(&buf)
+		if err := tt.initial.(json.MarshalerV2).MarshalJSONV2(enc, json.DefaultOptionsV2()); err != nil {
+			t.Fatalf("TestMarshalling(%s)(v2) failed: %v", tt.name, err)
+		}
+		if gotOutput := string(bytes.TrimSpace(buf.Bytes())); gotOutput != tt.wantOutput {
+			t.Errorf("TestMarshalling(%s)(v2) = %v, want %v", tt.name, gotOutput, tt.wantOutput)
+		}
+	}
+}
+
+func TestOfUnmarshalling(t *testing.T) {
+	t.Parallel()
+
+	type config struct {
+		Point Of[point]
+		Color Of[color]
+		Addr  Of[netip.Addr]
+	}
+
+	tests := []struct {
+		name      string
+		jsonInput string
+		want      config
+		wantErr   bool
+	}{
+		{
+			name:      "Unmarshal set values",
+			jsonInput: `{"Point":{"X":1,"Y":2},"Color":"red","Addr":"192.0.2.1"}`,
+			want: config{
+				Point: Of[point]{}.Set(point{X: 1, Y: 2}),
+				Color: Of[color]{}.Set(red),
+				Addr:  Of[netip.Addr]{}.Set(netip.MustParseAddr("192.0.2.1")),
+			},
+		},
+		{
+			name:      "Unmarshal null values",
+			jsonInput: `{"Point":null,"Color":null,"Addr":null}`,
+			want:      config{},
+		},
+		{
+			name:      "Unmarshal absent values",
+			jsonInput: `{}`,
+			want:      config{},
+		},
+		{
+			name:      "Unmarshal bad value",
+			jsonInput: `{"Color":"blue"}`,
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		for _, v := range []string{"v1", "v2"} {
+			var got config
+			var err error
+			if v == "v1" {
+				err = stdjson.Unmarshal([]byte(tt.jsonInput), &got)
+			} else {
+				err = json.Unmarshal([]byte(tt.jsonInput), &got)
+			}
+			switch {
+			case err == nil && tt.wantErr:
+				t.Errorf("TestOfUnmarshalling(%s)(%s): got err == nil, want err != nil", tt.name, v)
+				continue
+			case err != nil && !tt.wantErr:
+				t.Errorf("TestOfUnmarshalling(%s)(%s): got err == %s, want err == nil", tt.name, v, err)
+				continue
+			case err != nil:
+				continue
+			}
+			if got != tt.want {
+				t.Errorf("TestOfUnmarshalling(%s)(%s): got %+v, want %+v", tt.name, v, got, tt.want)
+			}
+		}
+	}
+}
+
+func TestOfText(t *testing.T) {
+	t.Parallel()
+
+	var addr Of[netip.Addr]
+	if err := addr.UnmarshalText([]byte("2001:db8::1")); err != nil {
+		t.Fatalf("TestOfText: UnmarshalText() failed: %v", err)
+	}
+	if !addr.IsSet() || addr.V() != netip.MustParseAddr("2001:db8::1") {
+		t.Errorf("TestOfText: got %v(set %v), want 2001:db8::1(set true)", addr.V(), addr.IsSet())
+	}
+	text, err := addr.MarshalText()
+	if err != nil || string(text) != "2001:db8::1" {
+		t.Errorf("TestOfText: MarshalText() = %q, %v, want %q", text, err, "2001:db8::1")
+	}
+
+	var p Of[point]
+	if err := p.UnmarshalText([]byte("1,2")); err == nil {
+		t.Errorf("TestOfText: UnmarshalText() on type without text methods got err == nil, want err != nil")
+	}
+	if p.IsSet() {
+		t.Errorf("TestOfText: IsSet() = true after error, want false")
+	}
+	if _, err := (Of[point]{}.Set(point{})).MarshalText(); err == nil {
+		t.Errorf("TestOfText: MarshalText() on type without text methods got err == nil, want err != nil")
+	}
+}
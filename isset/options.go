@@ -0,0 +1,184 @@
+package isset
+
+import (
+	"sync/atomic"
+
+	"github.com/go-json-experiment/json"
+	"github.com/go-json-experiment/json/jsontext"
+)
+
+// UnsetPolicy selects the JSON value written for an unset value.
+//
+// The policy does not decide if a value is written. IsZero reports true for every unset value, so an
+// encoder leaves unset values out of a JSON object when the struct field is tagged with `omitzero`,
+// whatever the policy. The policy applies where a value is written: in fields without the tag and in
+// slices and maps.
+type UnsetPolicy uint8
+
+const (
+	// UnsetOmit is for output where unset fields are left out with `omitzero`. Where a value must be
+	// written, it encodes unset values as JSON null, like UnsetNull. This is the default.
+	UnsetOmit UnsetPolicy = iota
+	// UnsetNull encodes unset values as JSON null.
+	UnsetNull
+	// UnsetZero encodes unset values as the zero value of the type, such as 0, false or "".
+	UnsetZero
+)
+
+// String implements fmt.Stringer.
+func (p UnsetPolicy) String() string {
+	switch p {
+	case UnsetOmit:
+		return "UnsetOmit"
+	case UnsetNull:
+		return "UnsetNull"
+	case UnsetZero:
+		return "UnsetZero"
+	}
+	return "UnsetPolicy(unknown)"
+}
+
+// Option is an option for encoding and decoding the types in this package. Options are passed to
+// JSONOptions for use with the v2 json package or to SetDefaults.
+type Option func(*options)
+
+// options holds the settings set by Option.
+type options struct {
+	unset     UnsetPolicy
+	lenient   bool
+	nonFinite bool
+	stringify bool
+	intern    bool
+}
+
+// WithUnsetPolicy sets the UnsetPolicy used when encoding unset values.
+func WithUnsetPolicy(p UnsetPolicy) Option {
+	return func(o *options) {
+		o.unset = p
+	}
+}
+
+// WithLenient enables lenient decoding for configuration written by hand. When enabled, Bool accepts
+// the strings "true", "yes", "on", "1" and their opposites, ignoring case, the numeric types accept
+// numbers in strings, including Go literals such as "0x1F", "0o17" and "1_000" for integers, and an
+// empty string decodes to an unset Bool, String or numeric type. Unlike in Go, a leading zero does not
+// mean octal, so "010" is 10. Float32 and Float64 only accept NaN and
+// infinities when WithNonFinite is also enabled. It is disabled by default.
+func WithLenient(enabled bool) Option {
+	return func(o *options) {
+		o.lenient = enabled
+	}
+}
+
+// WithNonFinite enables encoding NaN, +Inf and -Inf in Float32 and Float64 as the JSON strings "NaN",
+// "Infinity" and "-Infinity" and decoding those strings, like the `format:nonfinite` struct tag option
+// of the v2 json package, which the v2 methods also honor. When disabled, the default, encoding a
+// non-finite value returns an error.
+func WithNonFinite(enabled bool) Option {
+	return func(o *options) {
+		o.nonFinite = enabled
+	}
+}
+
+// WithStringifyNumbers enables encoding the numeric types as JSON strings, such as "9007199254740993",
+// which keeps the precision of 64-bit integers for JavaScript clients that decode numbers as float64.
+// Strings holding a number are accepted when decoding, in addition to numbers. The v2 methods also
+// honor the json.StringifyNumbers option and the `,string` struct tag option. It is disabled by default.
+func WithStringifyNumbers(enabled bool) Option {
+	return func(o *options) {
+		o.stringify = enabled
+	}
+}
+
+// WithInternStrings enables interning the values decoded into String with the unique package, so
+// equal strings share one allocation. This cuts the memory retained by documents that repeat the same
+// values, such as host or region names, at the cost of a lookup per value. It is disabled by default.
+func WithInternStrings(enabled bool) Option {
+	return func(o *options) {
+		o.intern = enabled
+	}
+}
+
+// defaults holds the options set with SetDefaults.
+var defaults atomic.Pointer[options]
+
+func init() {
+	defaults.Store(&options{})
+}
+
+// SetDefaults sets the package level options. They are used by the v1 MarshalJSON and UnmarshalJSON
+// methods, which cannot receive options, and by the v2 methods when no options were passed with
+// JSONOptions. Options not passed are reset to their default. SetDefaults is safe to call concurrently,
+// but is intended to be called once at program start.
+func SetDefaults(opts ...Option) {
+	defaults.Store(newOptions(opts))
+}
+
+// getDefaults returns the options set with SetDefaults.
+func getDefaults() *options {
+	return defaults.Load()
+}
+
+// JSONOptions returns json.Options that apply opts to the types in this package when passed to the v2
+// json package, such as json.Marshal(v, isset.JSONOptions(isset.WithUnsetPolicy(isset.UnsetZero))).
+// Options not passed use their default, not the value set with SetDefaults.
+//
+// The options are implemented with json.WithMarshalers and json.WithUnmarshalers. If you also use
+// those, the last one passed wins, so combine your marshalers with the ones from Marshalers and
+// Unmarshalers using json.NewMarshalers and json.NewUnmarshalers.
+func JSONOptions(opts ...Option) json.Options {
+	return json.JoinOptions(
+		json.WithMarshalers(Marshalers(opts...)),
+		json.WithUnmarshalers(Unmarshalers(opts...)),
+	)
+}
+
+// Marshalers returns the json.Marshalers that JSONOptions uses, for use with json.NewMarshalers.
+func Marshalers(opts ...Option) *json.Marshalers {
+	o := newOptions(opts)
+	return json.MarshalFuncV2(func(enc *jsontext.Encoder, v jsonMarshaler, opts json.Options) error {
+		return v.marshalJSONV2(enc, opts, o)
+	})
+}
+
+// Unmarshalers returns the json.Unmarshalers that JSONOptions uses, for use with json.NewUnmarshalers.
+func Unmarshalers(opts ...Option) *json.Unmarshalers {
+	o := newOptions(opts)
+	return json.UnmarshalFuncV2(func(dec *jsontext.Decoder, v jsonUnmarshaler, opts json.Options) error {
+		return v.unmarshalJSONV2(dec, opts, o)
+	})
+}
+
+// newOptions returns the options with opts applied to the defaults.
+func newOptions(opts []Option) *options {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// jsonMarshaler is implemented by the types in this package that honor options when encoding.
+type jsonMarshaler interface {
+	marshalJSONV2(enc *jsontext.Encoder, opts json.Options, o *options) error
+}
+
+// jsonUnmarshaler is implemented by pointers to the types in this package that honor options when decoding.
+type jsonUnmarshaler interface {
+	unmarshalJSONV2(dec *jsontext.Decoder, opts json.Options, o *options) error
+}
+
+// stringifyNumbers reports if the json.StringifyNumbers option, which the `,string` struct tag
+// option sets for a field, is set in opts.
+func stringifyNumbers(opts json.Options) bool {
+	if opts == defaultOpts {
+		return false
+	}
+	v, _ := json.GetOption(opts, json.StringifyNumbers)
+	return v
+}
+
+// writeNull reports if a value with the given isSet is written as a JSON null under o.
+func (o *options) writeNull(isSet bool) bool {
+	return !isSet && o.unset != UnsetZero
+}
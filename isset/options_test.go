@@ -0,0 +1,127 @@
+package isset
+
+import (
+	"testing"
+
+	"github.com/go-json-experiment/json"
+)
+
+type policyConfig struct {
+	Bool     Bool
+	String   String
+	Int8     Int8
+	Uint     Uint
+	Float64  Float64
+	Duration Duration
+	Bytes    Bytes
+	Of       Of[point]
+	Omitted  Int `json:",omitzero"`
+	Set      Int
+}
+
+const (
+	policyNullJSON = `{"Bool":null,"String":null,"Int8":null,"Uint":null,"Float64":null,"Duration":null,"Bytes":null,"Of":null,"Set":1}`
+	policyZeroJSON = `{"Bool":false,"String":"","Int8":0,"Uint":0,"Float64":0,"Duration":"0s","Bytes":"","Of":{"X":0,"Y":0},"Set":1}`
+)
+
+func TestJSONOptions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		opts json.Options
+		want string
+	}{
+		{
+			name: "No options",
+			want: policyNullJSON,
+		},
+		{
+			name: "UnsetOmit",
+			opts: JSONOptions(WithUnsetPolicy(UnsetOmit)),
+			want: policyNullJSON,
+		},
+		{
+			name: "UnsetNull",
+			opts: JSONOptions(WithUnsetPolicy(UnsetNull)),
+			want: policyNullJSON,
+		},
+		{
+			name: "UnsetZero",
+			opts: JSONOptions(WithUnsetPolicy(UnsetZero)),
+			want: policyZeroJSON,
+		},
+		{
+			name: "UnsetZero with other marshalers",
+			opts: json.WithMarshalers(json.NewMarshalers(
+				json.MarshalFuncV1(func(point) ([]byte, error) { return []byte(`"point"`), nil }),
+				Marshalers(WithUnsetPolicy(UnsetZero)),
+			)),
+			want: `{"Bool":false,"String":"","Int8":0,"Uint":0,"Float64":0,"Duration":"0s","Bytes":"","Of":"point","Set":1}`,
+		},
+	}
+
+	for _, tt := range tests {
+		v := policyConfig{Set: Int{}.Set(1)}
+		got, err := json.Marshal(v, tt.opts)
+		if err != nil {
+			t.Fatalf("TestJSONOptions(%s): Marshal() failed: %v", tt.name, err)
+		}
+		if string(got) != tt.want {
+			t.Errorf("TestJSONOptions(%s): Marshal() = %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
+
+// TestSetDefaults is not parallel, as it changes the package level options.
+func TestSetDefaults(t *testing.T) {
+	defer SetDefaults()
+
+	SetDefaults(WithUnsetPolicy(UnsetZero))
+
+	b, err := Int8{}.MarshalJSON()
+	if err != nil || string(b) != "0" {
+		t.Errorf("TestSetDefaults: MarshalJSON() = %s, %v, want 0", b, err)
+	}
+	v := policyConfig{Set: Int{}.Set(1)}
+	got, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("TestSetDefaults: Marshal() failed: %v", err)
+	}
+	// The policy only applies where a value is written, so the omitzero field is still omitted.
+	want := policyZeroJSON
+	if string(got) != want {
+		t.Errorf("TestSetDefaults: Marshal() = %s, want %s", got, want)
+	}
+
+	// JSONOptions do not inherit from the defaults.
+	got, err = json.Marshal(v, JSONOptions())
+	if err != nil {
+		t.Fatalf("TestSetDefaults: Marshal(JSONOptions()) failed: %v", err)
+	}
+	want = policyNullJSON
+	if string(got) != want {
+		t.Errorf("TestSetDefaults: Marshal(JSONOptions()) = %s, want %s", got, want)
+	}
+
+	SetDefaults(WithUnsetPolicy(UnsetNull))
+	got, err = json.Marshal(v)
+	if err != nil {
+		t.Fatalf("TestSetDefaults: Marshal() with UnsetNull failed: %v", err)
+	}
+	if string(got) != want {
+		t.Errorf("TestSetDefaults: Marshal() with UnsetNull = %s, want %s", got, want)
+	}
+	if !v.Omitted.IsZero() {
+		t.Errorf("TestSetDefaults: IsZero() with UnsetNull = false, want true")
+	}
+
+	SetDefaults()
+	b, err = Int8{}.MarshalJSON()
+	if err != nil || string(b) != "null" {
+		t.Errorf("TestSetDefaults: MarshalJSON() after reset = %s, %v, want null", b, err)
+	}
+	if !v.Omitted.IsZero() {
+		t.Errorf("TestSetDefaults: IsZero() after reset = false, want true")
+	}
+}
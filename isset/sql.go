@@ -0,0 +1,338 @@
+package isset
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+)
+
+// This file implements the sql.Scanner and driver.Valuer interfaces, so the types can be passed directly
+// to Scan and Exec for nullable columns. A SQL NULL scans to an unset value and an unset value is
+// written as NULL.
+
+// Scan implements the sql.Scanner interface. It accepts bool, int64 (0 or 1) and the values
+// strconv.ParseBool accepts in string or []byte form.
+func (i *Bool) Scan(src any) error {
+	var b bool
+	switch s := src.(type) {
+	case nil:
+		*i = i.Unset()
+		return nil
+	case bool:
+		b = s
+	case int64:
+		if s != 0 && s != 1 {
+			return scanRangeError(src, b)
+		}
+		b = s == 1
+	case string:
+		var err error
+		if b, err = strconv.ParseBool(s); err != nil {
+			return scanError(src, b, err)
+		}
+	case []byte:
+		var err error
+		if b, err = strconv.ParseBool(bytesToStr(s)); err != nil {
+			return scanError(src, b, err)
+		}
+	default:
+		return scanTypeError(src, b)
+	}
+	*i = i.Set(b)
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (i Bool) Value() (driver.Value, error) {
+	if !i.isSet {
+		return nil, nil
+	}
+	return i.v, nil
+}
+
+// Scan implements the sql.Scanner interface. It accepts string and []byte, as well as int64, float64,
+// bool and time.Time values, which are formatted as text.
+func (i *String) Scan(src any) error {
+	var str string
+	switch s := src.(type) {
+	case nil:
+		*i = i.Unset()
+		return nil
+	case string:
+		str = s
+	case []byte:
+		str = string(s)
+	case int64:
+		str = strconv.FormatInt(s, 10)
+	case float64:
+		str = strconv.FormatFloat(s, 'g', -1, 64)
+	case bool:
+		str = strconv.FormatBool(s)
+	case time.Time:
+		str = s.Format(time.RFC3339Nano)
+	default:
+		return scanTypeError(src, str)
+	}
+	*i = i.Set(str)
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (i String) Value() (driver.Value, error) {
+	if !i.isSet {
+		return nil, nil
+	}
+	return i.v, nil
+}
+
+// Scan implements the sql.Scanner interface. It accepts int64, integral float64 and base 10 integers in
+// string or []byte form. Values that do not fit in T are rejected.
+func (i *intType[T]) Scan(src any) error {
+	var zero T
+	var n int64
+	switch s := src.(type) {
+	case nil:
+		*i = i.Unset()
+		return nil
+	case int64:
+		n = s
+	case float64:
+		if s != math.Trunc(s) || s < math.MinInt64 || s >= math.MaxInt64 {
+			return scanRangeError(src, zero)
+		}
+		n = int64(s)
+	case string:
+		var err error
+		if n, err = strconv.ParseInt(s, 10, bitSize[T]()); err != nil {
+			return scanError(src, zero, err)
+		}
+	case []byte:
+		var err error
+		if n, err = strconv.ParseInt(bytesToStr(s), 10, bitSize[T]()); err != nil {
+			return scanError(src, zero, err)
+		}
+	default:
+		return scanTypeError(src, zero)
+	}
+	if int64(T(n)) != n {
+		return scanRangeError(src, zero)
+	}
+	*i = i.Set(T(n))
+	return nil
+}
+
+// Value implements the driver.Valuer interface. The value is returned as an int64.
+func (i intType[T]) Value() (driver.Value, error) {
+	if !i.isSet {
+		return nil, nil
+	}
+	return int64(i.v), nil
+}
+
+// Scan implements the sql.Scanner interface. It accepts non-negative int64, uint64, integral float64 and
+// base 10 integers in string or []byte form. Values that do not fit in T are rejected.
+func (i *uintType[T]) Scan(src any) error {
+	var zero T
+	var n uint64
+	switch s := src.(type) {
+	case nil:
+		*i = i.Unset()
+		return nil
+	case int64:
+		if s < 0 {
+			return scanRangeError(src, zero)
+		}
+		n = uint64(s)
+	case uint64:
+		n = s
+	case float64:
+		if s != math.Trunc(s) || s < 0 || s >= math.MaxUint64 {
+			return scanRangeError(src, zero)
+		}
+		n = uint64(s)
+	case string:
+		var err error
+		if n, err = strconv.ParseUint(s, 10, bitSize[T]()); err != nil {
+			return scanError(src, zero, err)
+		}
+	case []byte:
+		var err error
+		if n, err = strconv.ParseUint(bytesToStr(s), 10, bitSize[T]()); err != nil {
+			return scanError(src, zero, err)
+		}
+	default:
+		return scanTypeError(src, zero)
+	}
+	if uint64(T(n)) != n {
+		return scanRangeError(src, zero)
+	}
+	*i = i.Set(T(n))
+	return nil
+}
+
+// Value implements the driver.Valuer interface. The value is returned as an int64, so values with the
+// high bit set are not supported, the same as with database/sql for a uint64.
+func (i uintType[T]) Value() (driver.Value, error) {
+	if !i.isSet {
+		return nil, nil
+	}
+	if uint64(i.v) > math.MaxInt64 {
+		return nil, fmt.Errorf("isset: uint64 value %d with high bit set is not supported", uint64(i.v))
+	}
+	return int64(i.v), nil
+}
+
+// Scan implements the sql.Scanner interface. It accepts float64, int64 and numbers in string or []byte
+// form. Values that do not fit in T are rejected.
+func (i *floatType[T]) Scan(src any) error {
+	var zero T
+	var f float64
+	switch s := src.(type) {
+	case nil:
+		*i = i.Unset()
+		return nil
+	case float64:
+		f = s
+	case int64:
+		f = float64(s)
+	case string:
+		var err error
+		if f, err = strconv.ParseFloat(s, bitSize[T]()); err != nil {
+			return scanError(src, zero, err)
+		}
+	case []byte:
+		var err error
+		if f, err = strconv.ParseFloat(bytesToStr(s), bitSize[T]()); err != nil {
+			return scanError(src, zero, err)
+		}
+	default:
+		return scanTypeError(src, zero)
+	}
+	if bitSize[T]() == 32 && !math.IsInf(f, 0) && math.Abs(f) > math.MaxFloat32 {
+		return scanRangeError(src, zero)
+	}
+	*i = i.Set(T(f))
+	return nil
+}
+
+// Value implements the driver.Valuer interface. The value is returned as a float64.
+func (i floatType[T]) Value() (driver.Value, error) {
+	if !i.isSet {
+		return nil, nil
+	}
+	return float64(i.v), nil
+}
+
+// Scan implements the sql.Scanner interface. It accepts time.Time and RFC 3339 text in string or []byte
+// form.
+func (i *Time) Scan(src any) error {
+	var t time.Time
+	switch s := src.(type) {
+	case nil:
+		*i = i.Unset()
+		return nil
+	case time.Time:
+		t = s
+	case string:
+		var err error
+		if t, err = time.Parse(time.RFC3339, s); err != nil {
+			return scanError(src, t, err)
+		}
+	case []byte:
+		var err error
+		if t, err = time.Parse(time.RFC3339, string(s)); err != nil {
+			return scanError(src, t, err)
+		}
+	default:
+		return scanTypeError(src, t)
+	}
+	*i = i.Set(t)
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (i Time) Value() (driver.Value, error) {
+	if !i.isSet {
+		return nil, nil
+	}
+	return i.v, nil
+}
+
+// Scan implements the sql.Scanner interface. It accepts int64 nanoseconds and Go duration strings in
+// string or []byte form.
+func (i *Duration) Scan(src any) error {
+	var d time.Duration
+	switch s := src.(type) {
+	case nil:
+		*i = i.Unset()
+		return nil
+	case int64:
+		d = time.Duration(s)
+	case string:
+		var err error
+		if d, err = time.ParseDuration(s); err != nil {
+			return scanError(src, d, err)
+		}
+	case []byte:
+		var err error
+		if d, err = time.ParseDuration(bytesToStr(s)); err != nil {
+			return scanError(src, d, err)
+		}
+	default:
+		return scanTypeError(src, d)
+	}
+	*i = i.Set(d)
+	return nil
+}
+
+// Value implements the driver.Valuer interface. The value is returned as int64 nanoseconds.
+func (i Duration) Value() (driver.Value, error) {
+	if !i.isSet {
+		return nil, nil
+	}
+	return int64(i.v), nil
+}
+
+// Scan implements the sql.Scanner interface. It accepts []byte and string. The bytes are copied, as the
+// driver owns the memory of a []byte src.
+func (i *Bytes) Scan(src any) error {
+	var b []byte
+	switch s := src.(type) {
+	case nil:
+		*i = i.Unset()
+		return nil
+	case []byte:
+		b = append([]byte{}, s...)
+	case string:
+		b = []byte(s)
+	default:
+		return scanTypeError(src, b)
+	}
+	*i = i.Set(b)
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (i Bytes) Value() (driver.Value, error) {
+	if !i.isSet {
+		return nil, nil
+	}
+	if i.v == nil {
+		return []byte{}, nil
+	}
+	return i.v, nil
+}
+
+func scanTypeError(src, dst any) error {
+	return fmt.Errorf("isset: unsupported Scan, storing driver.Value type %T into type %T", src, dst)
+}
+
+func scanRangeError(src, dst any) error {
+	return fmt.Errorf("isset: converting driver.Value type %T (%v) to a %T: value out of range", src, src, dst)
+}
+
+func scanError(src, dst any, err error) error {
+	return fmt.Errorf("isset: converting driver.Value type %T to a %T: %w", src, dst, err)
+}
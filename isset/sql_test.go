@@ -0,0 +1,207 @@
+package isset
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"math"
+	"testing"
+	"time"
+)
+
+var (
+	_ sql.Scanner   = (*Int8)(nil)
+	_ driver.Valuer = Int8{}
+	_ sql.Scanner   = (*Uint64)(nil)
+	_ driver.Valuer = Uint64{}
+	_ sql.Scanner   = (*Float32)(nil)
+	_ driver.Valuer = Float32{}
+)
+
+// scanner is implemented by pointers to the types in this package.
+type scanner interface {
+	sql.Scanner
+	IsSet() bool
+}
+
+func TestScan(t *testing.T) {
+	t.Parallel()
+
+	ts := time.Date(2024, 12, 30, 15, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		dst     scanner
+		src     any
+		want    any
+		wantErr bool
+	}{
+		{name: "Bool NULL", dst: new(Bool), src: nil},
+		{name: "Bool bool", dst: new(Bool), src: true, want: true},
+		{name: "Bool int64", dst: new(Bool), src: int64(0), want: false},
+		{name: "Bool bytes", dst: new(Bool), src: []byte("t"), want: true},
+		{name: "Bool int64 out of range", dst: new(Bool), src: int64(2), wantErr: true},
+		{name: "String NULL", dst: new(String), src: nil},
+		{name: "String bytes", dst: new(String), src: []byte("hello"), want: "hello"},
+		{name: "String empty", dst: new(String), src: "", want: ""},
+		{name: "String int64", dst: new(String), src: int64(42), want: "42"},
+		{name: "Int NULL", dst: new(Int), src: nil},
+		{name: "Int int64", dst: new(Int), src: int64(42), want: 42},
+		{name: "Int8 int64", dst: new(Int8), src: int64(-128), want: int8(-128)},
+		{name: "Int8 int64 out of range", dst: new(Int8), src: int64(300), wantErr: true},
+		{name: "Int16 bytes", dst: new(Int16), src: []byte("-42"), want: int16(-42)},
+		{name: "Int16 bytes out of range", dst: new(Int16), src: []byte("40000"), wantErr: true},
+		{name: "Int32 float64", dst: new(Int32), src: float64(7), want: int32(7)},
+		{name: "Int32 fractional float64", dst: new(Int32), src: 7.5, wantErr: true},
+		{name: "Int64 bool", dst: new(Int64), src: true, wantErr: true},
+		{name: "Uint NULL", dst: new(Uint), src: nil},
+		{name: "Uint8 int64", dst: new(Uint8), src: int64(255), want: uint8(255)},
+		{name: "Uint8 int64 out of range", dst: new(Uint8), src: int64(256), wantErr: true},
+		{name: "Uint32 negative int64", dst: new(Uint32), src: int64(-1), wantErr: true},
+		{name: "Uint64 uint64", dst: new(Uint64), src: uint64(math.MaxUint64), want: uint64(math.MaxUint64)},
+		{name: "Uint64 string", dst: new(Uint64), src: "18446744073709551615", want: uint64(math.MaxUint64)},
+		{name: "Float32 NULL", dst: new(Float32), src: nil},
+		{name: "Float32 float64", dst: new(Float32), src: 1.5, want: float32(1.5)},
+		{name: "Float32 float64 out of range", dst: new(Float32), src: 1e300, wantErr: true},
+		{name: "Float64 int64", dst: new(Float64), src: int64(3), want: float64(3)},
+		{name: "Float64 bytes", dst: new(Float64), src: []byte("2.5"), want: 2.5},
+		{name: "Time time", dst: new(Time), src: ts, want: ts},
+		{name: "Time string", dst: new(Time), src: "2024-12-30T15:04:05Z", want: ts},
+		{name: "Duration int64", dst: new(Duration), src: int64(time.Second), want: time.Second},
+		{name: "Duration bytes", dst: new(Duration), src: []byte("1m"), want: time.Minute},
+		{name: "Bytes NULL", dst: new(Bytes), src: nil},
+		{name: "Bytes int64", dst: new(Bytes), src: int64(1), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		err := tt.dst.Scan(tt.src)
+		switch {
+		case err == nil && tt.wantErr:
+			t.Errorf("TestScan(%s): got err == nil, want err != nil", tt.name)
+			continue
+		case err != nil && !tt.wantErr:
+			t.Errorf("TestScan(%s): got err == %s, want err == nil", tt.name, err)
+			continue
+		case err != nil:
+			if tt.dst.IsSet() {
+				t.Errorf("TestScan(%s): IsSet() = true after error, want false", tt.name)
+			}
+			continue
+		}
+
+		if got := tt.dst.IsSet(); got != (tt.want != nil) {
+			t.Errorf("TestScan(%s): IsSet() = %v, want %v", tt.name, got, tt.want != nil)
+		}
+		if tt.want == nil {
+			continue
+		}
+		got := valueOf(tt.dst)
+		if got != tt.want {
+			t.Errorf("TestScan(%s): V() = %v(%T), want %v(%T)", tt.name, got, got, tt.want, tt.want)
+		}
+	}
+}
+
+// valueOf returns the result of the V method of the value pointed to by p.
+func valueOf(p any) any {
+	switch v := p.(type) {
+	case *Bool:
+		return v.V()
+	case *String:
+		return v.V()
+	case *Int:
+		return v.V()
+	case *Int8:
+		return v.V()
+	case *Int16:
+		return v.V()
+	case *Int32:
+		return v.V()
+	case *Int64:
+		return v.V()
+	case *Uint:
+		return v.V()
+	case *Uint8:
+		return v.V()
+	case *Uint16:
+		return v.V()
+	case *Uint32:
+		return v.V()
+	case *Uint64:
+		return v.V()
+	case *Float32:
+		return v.V()
+	case *Float64:
+		return v.V()
+	case *Time:
+		return v.V()
+	case *Duration:
+		return v.V()
+	}
+	panic("unsupported type")
+}
+
+func TestScanBytesCopies(t *testing.T) {
+	t.Parallel()
+
+	src := []byte("driver owned")
+	var b Bytes
+	if err := b.Scan(src); err != nil {
+		t.Fatalf("TestScanBytesCopies: Scan() failed: %v", err)
+	}
+	src[0] = 'X'
+	if got := string(b.V()); got != "driver owned" {
+		t.Errorf("TestScanBytesCopies: V() = %q after driver reused buffer, want %q", got, "driver owned")
+	}
+}
+
+func TestValue(t *testing.T) {
+	t.Parallel()
+
+	ts := time.Date(2024, 12, 30, 15, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		v       driver.Valuer
+		want    driver.Value
+		wantErr bool
+	}{
+		{name: "Bool unset", v: Bool{}, want: nil},
+		{name: "Bool set", v: Bool{}.Set(false), want: false},
+		{name: "String unset", v: String{}, want: nil},
+		{name: "String set", v: String{}.Set(""), want: ""},
+		{name: "Int8 set", v: Int8{}.Set(-1), want: int64(-1)},
+		{name: "Int unset", v: Int{}, want: nil},
+		{name: "Uint16 set", v: Uint16{}.Set(65535), want: int64(65535)},
+		{name: "Uint64 high bit", v: Uint64{}.Set(math.MaxUint64), wantErr: true},
+		{name: "Float32 set", v: Float32{}.Set(0.5), want: float64(0.5)},
+		{name: "Float64 unset", v: Float64{}, want: nil},
+		{name: "Time set", v: Time{}.Set(ts), want: ts},
+		{name: "Duration set", v: Duration{}.Set(time.Second), want: int64(time.Second)},
+		{name: "Bytes unset", v: Bytes{}, want: nil},
+	}
+
+	for _, tt := range tests {
+		got, err := tt.v.Value()
+		switch {
+		case err == nil && tt.wantErr:
+			t.Errorf("TestValue(%s): got err == nil, want err != nil", tt.name)
+			continue
+		case err != nil && !tt.wantErr:
+			t.Errorf("TestValue(%s): got err == %s, want err == nil", tt.name, err)
+			continue
+		case err != nil:
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("TestValue(%s): Value() = %v(%T), want %v(%T)", tt.name, got, got, tt.want, tt.want)
+		}
+		if !driver.IsValue(got) {
+			t.Errorf("TestValue(%s): Value() returned %T, which is not a driver.Value", tt.name, got)
+		}
+	}
+
+	b, err := Bytes{}.Set(nil).Value()
+	if err != nil || b == nil {
+		t.Errorf("TestValue(Bytes set nil): Value() = %v, %v, want empty []byte", b, err)
+	}
+}
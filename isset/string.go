@@ -1,8 +1,6 @@
 package isset
 
 import (
-	"fmt"
-
 	"github.com/go-json-experiment/json"
 	"github.com/go-json-experiment/json/jsontext"
 )
@@ -23,6 +21,11 @@ func (i String) IsSet() bool {
 	return i.isSet
 }
 
+// IsZero reports if the value is unset.
+func (i String) IsZero() bool {
+	return !i.isSet
+}
+
 // Set sets the value and marks it as set.
 func (i String) Set(val string) String {
 	i.v = val
@@ -39,50 +42,135 @@ func (i String) Unset() String {
 
 // MarshalJSON implements the json.Marshaler interface.
 func (i String) MarshalJSON() ([]byte, error) {
-	if !i.isSet {
-		return []byte{}, nil
+	return i.AppendJSON(make([]byte, 0, len(i.v)+2))
+}
+
+// AppendJSON appends the JSON encoding returned by MarshalJSON to dst. It lets encoders write the
+// value into their own buffer without allocating.
+func (i String) AppendJSON(dst []byte) ([]byte, error) {
+	return i.appendJSON(dst, getDefaults())
+}
+
+// appendJSON is AppendJSON with the options in o.
+func (i String) appendJSON(dst []byte, o *options) ([]byte, error) {
+	if o.writeNull(i.isSet) {
+		return append(dst, "null"...), nil
 	}
-	return json.Marshal(i.v)
+	return jsontext.AppendQuote(dst, i.v)
 }
 
 // MarshalJSONV2 implements the json.MarshalerV2 interface.
 func (i String) MarshalJSONV2(enc *jsontext.Encoder, opts json.Options) error {
+	return i.marshalJSONV2(enc, opts, getDefaults())
+}
+
+func (i String) marshalJSONV2(enc *jsontext.Encoder, opts json.Options, o *options) error {
+	if o.writeNull(i.isSet) {
+		return enc.WriteToken(jsontext.Null)
+	}
+	format, err := formatOf(opts, enc.StackDepth())
+	if err != nil {
+		return err
+	}
+	if format != "" {
+		return invalidFormatError("isset.String", format)
+	}
 	return enc.WriteToken(jsontext.String(string(i.v)))
 }
 
 // UnmarshalJSON implements the json.Unmarshaler interface.
 func (i *String) UnmarshalJSON(data []byte) error {
+	return i.unmarshalJSON(data, getDefaults())
+}
+
+// unmarshalJSON is UnmarshalJSON with the options in o.
+func (i *String) unmarshalJSON(data []byte, o *options) error {
 	if bytesToStr(data) == "null" {
 		i.isSet = false
 		i.v = ""
 		return nil
 	}
 
-	i.isSet = true
-	var t string
-	if err := json.Unmarshal(data, &t); err != nil {
-		return err
+	if jsontext.Value(data).Kind() != '"' {
+		return decodeErrorV1("isset.String", '"', data, nil)
+	}
+	t, err := unquote(data, o.intern)
+	if err != nil {
+		return decodeErrorV1("isset.String", '"', data, err)
+	}
+	if t == "" && o.lenient {
+		*i = i.Unset()
+		return nil
 	}
 	i.v = t
+	i.isSet = true
 	return nil
 }
 
 // UnmarshalJSONV2 implements the json.UnmarshalerV2 interface.
 func (v *String) UnmarshalJSONV2(dec *jsontext.Decoder, opts json.Options) error {
-	t, err := dec.ReadToken()
+	return v.unmarshalJSONV2(dec, opts, getDefaults())
+}
+
+func (v *String) unmarshalJSONV2(dec *jsontext.Decoder, opts json.Options, o *options) error {
+	format, err := formatOf(opts, dec.StackDepth())
+	if err != nil {
+		return err
+	}
+	if format != "" {
+		return invalidFormatError("isset.String", format)
+	}
+
+	// ReadValue returns the raw value in the decoder's buffer, which unquote copies at most once,
+	// instead of a token that holds its own copy.
+	val, err := dec.ReadValue()
 	if err != nil {
 		return err
 	}
 
-	switch t.Kind() {
+	switch val.Kind() {
 	case 'n':
 		v.isSet = false
 		v.v = ""
 		return nil
 	case '"':
+		s, err := unquote(val, o.intern)
+		if err != nil {
+			// The decoder only lets invalid UTF-8 through with AllowInvalidUTF8, which replaces it.
+			if allow, _ := json.GetOption(opts, jsontext.AllowInvalidUTF8); !allow {
+				return decodeError(dec, "isset.String", '"', '"', err)
+			}
+			b, _ := jsontext.AppendUnquote(nil, val)
+			s = string(b)
+		}
+		if s == "" && o.lenient {
+			*v = v.Unset()
+			return nil
+		}
 		v.isSet = true
-		v.v = string(t.String())
+		v.v = s
 		return nil
 	}
-	return fmt.Errorf("expected a JSON string, got %string", t.Kind())
+	return decodeError(dec, "isset.String", '"', val.Kind(), nil)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface. An unset value marshals to empty text.
+func (i String) MarshalText() ([]byte, error) {
+	return i.AppendText(nil)
+}
+
+// AppendText implements the encoding.TextAppender interface.
+func (i String) AppendText(b []byte) ([]byte, error) {
+	if !i.isSet {
+		return b, nil
+	}
+	return append(b, i.v...), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface. It marks the value as set, even if
+// the text is empty, so an unset String, which MarshalText writes as empty text, decodes as a set "".
+func (i *String) UnmarshalText(text []byte) error {
+	i.v = string(text)
+	i.isSet = true
+	return nil
 }
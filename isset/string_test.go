@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"testing"
+	"unsafe"
 
 	"github.com/go-json-experiment/json"
 	"github.com/go-json-experiment/json/jsontext"
@@ -96,7 +97,7 @@ func TestStringMarshalling(t *testing.T) {
 			jsonInput:  "",
 			wantStr:    "",
 			wantIsSet:  false,
-			wantOutput: "",
+			wantOutput: "null",
 		},
 		{
 			name:       "Unmarshal set String",
@@ -161,3 +162,125 @@ func TestStringMarshalling(t *testing.T) {
 		}
 	}
 }
+
+func TestStringText(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		text     string
+		want     String
+		wantErr  bool
+		wantText string
+	}{
+		{name: "text", text: "hello", want: String{}.Set("hello"), wantText: "hello"},
+		{name: "empty", text: "", want: String{}.Set(""), wantText: ""},
+	}
+
+	for _, tt := range tests {
+		var v String
+		err := v.UnmarshalText([]byte(tt.text))
+		switch {
+		case err == nil && tt.wantErr:
+			t.Errorf("TestStringText(%s): got err == nil, want err != nil", tt.name)
+			continue
+		case err != nil && !tt.wantErr:
+			t.Errorf("TestStringText(%s): got err == %s, want err == nil", tt.name, err)
+			continue
+		case err != nil:
+			if v.IsSet() {
+				t.Errorf("TestStringText(%s): IsSet() = true after error, want false", tt.name)
+			}
+			continue
+		}
+		if v != tt.want {
+			t.Errorf("TestStringText(%s): got %v(set %v), want %v(set %v)", tt.name, v.V(), v.IsSet(), tt.want.V(), tt.want.IsSet())
+		}
+
+		text, err := v.MarshalText()
+		if err != nil {
+			t.Fatalf("TestStringText(%s): MarshalText() failed: %v", tt.name, err)
+		}
+		if string(text) != tt.wantText {
+			t.Errorf("TestStringText(%s): MarshalText() = %q, want %q", tt.name, text, tt.wantText)
+		}
+	}
+
+	text, err := String{}.MarshalText()
+	if err != nil || len(text) != 0 {
+		t.Errorf("TestStringText(unset): MarshalText() = %q, %v, want empty text", text, err)
+	}
+	b, err := String{}.Set("hello").AppendText([]byte("x="))
+	if err != nil || string(b) != "x=hello" {
+		t.Errorf("TestStringText(append): AppendText() = %q, %v, want %q", b, err, "x=hello")
+	}
+}
+
+func TestStringIntern(t *testing.T) {
+	t.Parallel()
+
+	data := []byte(`["us-east-1", "us-east-1", "us-east-1", "eu-west-1"]`)
+
+	tests := []struct {
+		name       string
+		opts       []Option
+		wantShared bool
+	}{
+		{name: "Default"},
+		{name: "WithInternStrings(false)", opts: []Option{WithInternStrings(false)}},
+		{name: "WithInternStrings(true)", opts: []Option{WithInternStrings(true)}, wantShared: true},
+	}
+
+	for _, tt := range tests {
+		var got []String
+		if err := json.Unmarshal(data, &got, JSONOptions(tt.opts...)); err != nil {
+			t.Errorf("TestStringIntern(%s): Unmarshal() failed: %v", tt.name, err)
+			continue
+		}
+		want := []string{"us-east-1", "us-east-1", "us-east-1", "eu-west-1"}
+		for i, s := range got {
+			if !s.IsSet() || s.V() != want[i] {
+				t.Errorf("TestStringIntern(%s): got[%d] = %q(set %v), want %q(set true)", tt.name, i, s.V(), s.IsSet(), want[i])
+			}
+		}
+		if len(got) != len(want) {
+			continue
+		}
+		for i := 1; i < 3; i++ {
+			shared := unsafe.StringData(got[0].V()) == unsafe.StringData(got[i].V())
+			if shared != tt.wantShared {
+				t.Errorf("TestStringIntern(%s): got[0] and got[%d] share memory = %v, want %v", tt.name, i, shared, tt.wantShared)
+			}
+		}
+	}
+}
+
+func TestStringInternDefaults(t *testing.T) {
+	defer SetDefaults()
+	SetDefaults(WithInternStrings(true))
+
+	var a, b String
+	if err := a.UnmarshalJSON([]byte(`"host-1"`)); err != nil {
+		t.Fatalf("TestStringInternDefaults: UnmarshalJSON() failed: %v", err)
+	}
+	if err := b.UnmarshalJSON([]byte(`"host-1"`)); err != nil {
+		t.Fatalf("TestStringInternDefaults: UnmarshalJSON() failed: %v", err)
+	}
+	if a.V() != "host-1" || unsafe.StringData(a.V()) != unsafe.StringData(b.V()) {
+		t.Errorf("TestStringInternDefaults: got %q and %q, want equal strings sharing memory", a.V(), b.V())
+	}
+}
+
+func TestStringInvalidUTF8(t *testing.T) {
+	t.Parallel()
+
+	data := []byte("\"a\xffb\"")
+
+	var s String
+	if err := json.Unmarshal(data, &s); err == nil {
+		t.Errorf("TestStringInvalidUTF8: Unmarshal() succeeded, want error")
+	}
+	if err := json.Unmarshal(data, &s, jsontext.AllowInvalidUTF8(true)); err != nil || s.V() != "a�b" {
+		t.Errorf("TestStringInvalidUTF8: Unmarshal(AllowInvalidUTF8) = %q, %v, want %q", s.V(), err, "a�b")
+	}
+}
@@ -0,0 +1,378 @@
+package isset
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-json-experiment/json"
+	"github.com/go-json-experiment/json/jsontext"
+)
+
+// Time is a type representing a time.Time that can be set or unset.
+// It is encoded in JSON as an RFC 3339 string.
+type Time struct {
+	v     time.Time
+	isSet bool
+}
+
+// V returns the value.
+func (i Time) V() time.Time {
+	return i.v
+}
+
+// IsSet returns if the value was set.
+func (i Time) IsSet() bool {
+	return i.isSet
+}
+
+// IsZero reports if the value is unset.
+func (i Time) IsZero() bool {
+	return !i.isSet
+}
+
+// Set sets the value and marks it as set.
+func (i Time) Set(val time.Time) Time {
+	i.v = val
+	i.isSet = true
+	return i
+}
+
+// Unset retuns the value to its zero value and marks it as unset.
+func (i Time) Unset() Time {
+	i.v = time.Time{}
+	i.isSet = false
+	return i
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (i Time) MarshalJSON() ([]byte, error) {
+	return i.AppendJSON(make([]byte, 0, len(time.RFC3339Nano)+2))
+}
+
+// AppendJSON appends the JSON encoding returned by MarshalJSON to dst. It lets encoders write the
+// value into their own buffer without allocating.
+func (i Time) AppendJSON(dst []byte) ([]byte, error) {
+	return i.appendJSON(dst, getDefaults())
+}
+
+// appendJSON is AppendJSON with the options in o.
+func (i Time) appendJSON(dst []byte, o *options) ([]byte, error) {
+	if o.writeNull(i.isSet) {
+		return append(dst, "null"...), nil
+	}
+	if err := checkYear(i.v); err != nil {
+		return dst, err
+	}
+	dst = append(dst, '"')
+	dst = i.v.AppendFormat(dst, time.RFC3339Nano)
+	return append(dst, '"'), nil
+}
+
+// checkYear returns an error if the year of t is outside of [0,9999], which RFC 3339 cannot represent.
+// time.Time.MarshalJSON rejects these years in the same way.
+func checkYear(t time.Time) error {
+	if y := t.Year(); y < 0 || y > 9999 {
+		return fmt.Errorf("isset: cannot encode isset.Time: year %d outside of range [0,9999]", y)
+	}
+	return nil
+}
+
+// MarshalJSONV2 implements the json.MarshalerV2 interface.
+func (i Time) MarshalJSONV2(enc *jsontext.Encoder, opts json.Options) error {
+	return i.marshalJSONV2(enc, opts, getDefaults())
+}
+
+func (i Time) marshalJSONV2(enc *jsontext.Encoder, opts json.Options, o *options) error {
+	if o.writeNull(i.isSet) {
+		return enc.WriteToken(jsontext.Null)
+	}
+	format, err := formatOf(opts, enc.StackDepth())
+	if err != nil {
+		return err
+	}
+	if format != "" {
+		return json.MarshalEncode(enc, i.v, opts)
+	}
+	if err := checkYear(i.v); err != nil {
+		return err
+	}
+	return enc.WriteToken(jsontext.String(i.v.Format(time.RFC3339Nano)))
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (i *Time) UnmarshalJSON(data []byte) error {
+	if bytesToStr(data) == "null" {
+		i.isSet = false
+		i.v = time.Time{}
+		return nil
+	}
+
+	if jsontext.Value(data).Kind() != '"' {
+		return decodeErrorV1("isset.Time", '"', data, nil)
+	}
+	s, err := unquote(data, false)
+	if err != nil {
+		return decodeErrorV1("isset.Time", '"', data, err)
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return decodeErrorV1("isset.Time", '"', data, err)
+	}
+	i.v = t
+	i.isSet = true
+	return nil
+}
+
+// UnmarshalJSONV2 implements the json.UnmarshalerV2 interface.
+func (v *Time) UnmarshalJSONV2(dec *jsontext.Decoder, opts json.Options) error {
+	format, err := formatOf(opts, dec.StackDepth())
+	if err != nil {
+		return err
+	}
+	if format != "" && dec.PeekKind() != 'n' {
+		var tm time.Time
+		if err := json.UnmarshalDecode(dec, &tm, opts); err != nil {
+			return err
+		}
+		*v = v.Set(tm)
+		return nil
+	}
+
+	t, err := dec.ReadToken()
+	if err != nil {
+		return err
+	}
+
+	switch t.Kind() {
+	case 'n':
+		v.isSet = false
+		v.v = time.Time{}
+		return nil
+	case '"':
+		tm, err := time.Parse(time.RFC3339, t.String())
+		if err != nil {
+			return decodeError(dec, "isset.Time", '"', '"', err)
+		}
+		v.isSet = true
+		v.v = tm
+		return nil
+	}
+	return decodeError(dec, "isset.Time", '"', t.Kind(), nil)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface. An unset value marshals to empty text.
+func (i Time) MarshalText() ([]byte, error) {
+	return i.AppendText(nil)
+}
+
+// AppendText implements the encoding.TextAppender interface.
+func (i Time) AppendText(b []byte) ([]byte, error) {
+	if !i.isSet {
+		return b, nil
+	}
+	if err := checkYear(i.v); err != nil {
+		return b, err
+	}
+	return i.v.AppendFormat(b, time.RFC3339Nano), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface. It accepts RFC 3339 text and marks
+// the value as set on success. Empty text, which MarshalText writes for an unset value, makes the value
+// unset.
+func (i *Time) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*i = i.Unset()
+		return nil
+	}
+	t, err := time.Parse(time.RFC3339, bytesToStr(text))
+	if err != nil {
+		return err
+	}
+	i.v = t
+	i.isSet = true
+	return nil
+}
+
+// Duration is a type representing a time.Duration that can be set or unset.
+// It is encoded in JSON as a Go duration string such as "1m30s". When decoding,
+// both duration strings and JSON integers holding nanoseconds are accepted.
+type Duration struct {
+	v     time.Duration
+	isSet bool
+}
+
+// V returns the value.
+func (i Duration) V() time.Duration {
+	return i.v
+}
+
+// IsSet returns if the value was set.
+func (i Duration) IsSet() bool {
+	return i.isSet
+}
+
+// IsZero reports if the value is unset.
+func (i Duration) IsZero() bool {
+	return !i.isSet
+}
+
+// Set sets the value and marks it as set.
+func (i Duration) Set(val time.Duration) Duration {
+	i.v = val
+	i.isSet = true
+	return i
+}
+
+// Unset retuns the value to its zero value and marks it as unset.
+func (i Duration) Unset() Duration {
+	i.v = 0
+	i.isSet = false
+	return i
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (i Duration) MarshalJSON() ([]byte, error) {
+	return i.AppendJSON(make([]byte, 0, 34))
+}
+
+// AppendJSON appends the JSON encoding returned by MarshalJSON to dst. It lets encoders write the
+// value into their own buffer without allocating.
+func (i Duration) AppendJSON(dst []byte) ([]byte, error) {
+	return i.appendJSON(dst, getDefaults())
+}
+
+// appendJSON is AppendJSON with the options in o.
+func (i Duration) appendJSON(dst []byte, o *options) ([]byte, error) {
+	if o.writeNull(i.isSet) {
+		return append(dst, "null"...), nil
+	}
+	dst = append(dst, '"')
+	dst = append(dst, i.v.String()...)
+	return append(dst, '"'), nil
+}
+
+// MarshalJSONV2 implements the json.MarshalerV2 interface.
+func (i Duration) MarshalJSONV2(enc *jsontext.Encoder, opts json.Options) error {
+	return i.marshalJSONV2(enc, opts, getDefaults())
+}
+
+func (i Duration) marshalJSONV2(enc *jsontext.Encoder, opts json.Options, o *options) error {
+	if o.writeNull(i.isSet) {
+		return enc.WriteToken(jsontext.Null)
+	}
+	format, err := formatOf(opts, enc.StackDepth())
+	if err != nil {
+		return err
+	}
+	if format != "" {
+		return json.MarshalEncode(enc, i.v, opts)
+	}
+	return enc.WriteToken(jsontext.String(i.v.String()))
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (i *Duration) UnmarshalJSON(data []byte) error {
+	s := bytesToStr(data)
+	if s == "null" {
+		i.isSet = false
+		i.v = 0
+		return nil
+	}
+
+	var d time.Duration
+	switch jsontext.Value(data).Kind() {
+	case '"':
+		str, err := unquote(data, false)
+		if err != nil {
+			return decodeErrorV1("isset.Duration", '"', data, err)
+		}
+		if d, err = time.ParseDuration(str); err != nil {
+			return decodeErrorV1("isset.Duration", '"', data, err)
+		}
+	case '0':
+		var err error
+		if d, err = parseInt[time.Duration](s, 10); err != nil {
+			return decodeErrorV1("isset.Duration", '0', data, err)
+		}
+	default:
+		return decodeErrorV1("isset.Duration", '"', data, nil)
+	}
+	i.v = d
+	i.isSet = true
+	return nil
+}
+
+// UnmarshalJSONV2 implements the json.UnmarshalerV2 interface.
+func (v *Duration) UnmarshalJSONV2(dec *jsontext.Decoder, opts json.Options) error {
+	format, err := formatOf(opts, dec.StackDepth())
+	if err != nil {
+		return err
+	}
+	if format != "" && dec.PeekKind() != 'n' {
+		var d time.Duration
+		if err := json.UnmarshalDecode(dec, &d, opts); err != nil {
+			return err
+		}
+		*v = v.Set(d)
+		return nil
+	}
+
+	t, err := dec.ReadToken()
+	if err != nil {
+		return err
+	}
+
+	switch t.Kind() {
+	case 'n':
+		v.isSet = false
+		v.v = 0
+		return nil
+	case '"':
+		d, err := time.ParseDuration(t.String())
+		if err != nil {
+			return decodeError(dec, "isset.Duration", '"', '"', err)
+		}
+		v.isSet = true
+		v.v = d
+		return nil
+	case '0':
+		d, err := parseInt[time.Duration](t.String(), 10)
+		if err != nil {
+			return decodeError(dec, "isset.Duration", '0', '0', err)
+		}
+		v.isSet = true
+		v.v = d
+		return nil
+	}
+	return decodeError(dec, "isset.Duration", '"', t.Kind(), nil)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface. An unset value marshals to empty text.
+func (i Duration) MarshalText() ([]byte, error) {
+	return i.AppendText(nil)
+}
+
+// AppendText implements the encoding.TextAppender interface.
+func (i Duration) AppendText(b []byte) ([]byte, error) {
+	if !i.isSet {
+		return b, nil
+	}
+	return append(b, i.v.String()...), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface. It accepts Go duration strings such
+// as "1m30s" and marks the value as set on success. Empty text, which MarshalText writes for an unset
+// value, makes the value unset.
+func (i *Duration) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*i = i.Unset()
+		return nil
+	}
+	d, err := time.ParseDuration(bytesToStr(text))
+	if err != nil {
+		return err
+	}
+	i.v = d
+	i.isSet = true
+	return nil
+}
@@ -0,0 +1,481 @@
+package isset
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/go-json-experiment/json"
+	"github.com/go-json-experiment/json/jsontext"
+)
+
+func TestTime(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 12, 30, 15, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		operation func() Time
+		wantTime  time.Time
+		wantIsSet bool
+	}{
+		{
+			name: "Set Time",
+			operation: func() Time {
+				var v Time
+				return v.Set(now)
+			},
+			wantTime:  now,
+			wantIsSet: true,
+		},
+		{
+			name: "Unset Time",
+			operation: func() Time {
+				var v Time
+				v = v.Set(now)
+				return v.Unset()
+			},
+			wantTime:  time.Time{}, // Default zero Time
+			wantIsSet: false,
+		},
+		{
+			name: "Default Time",
+			operation: func() Time {
+				return Time{}
+			},
+			wantTime:  time.Time{}, // Default zero Time
+			wantIsSet: false,
+		},
+		{
+			name: "Set the zero Time",
+			operation: func() Time {
+				var v Time
+				return v.Set(time.Time{})
+			},
+			wantTime:  time.Time{},
+			wantIsSet: true,
+		},
+	}
+
+	for _, tt := range tests {
+		v := tt.operation()
+		if got := v.V(); !got.Equal(tt.wantTime) {
+			t.Errorf("TestTime(%s): V() = %v, want %v", tt.name, got, tt.wantTime)
+		}
+		if got := v.IsSet(); got != tt.wantIsSet {
+			t.Errorf("TestTime(%s): IsSet() = %v, want %v", tt.name, got, tt.wantIsSet)
+		}
+	}
+}
+
+func TestTimeMarshalling(t *testing.T) {
+	t.Parallel()
+
+	ts := time.Date(2024, 12, 30, 15, 4, 5, 500, time.UTC)
+
+	tests := []struct {
+		name       string
+		initial    Time
+		jsonInput  string
+		wantTime   time.Time
+		wantIsSet  bool
+		wantOutput string
+		wantErr    bool
+	}{
+		{
+			name:       "Marshal set Time",
+			initial:    Time{}.Set(ts),
+			wantTime:   ts,
+			wantIsSet:  true,
+			wantOutput: `"2024-12-30T15:04:05.0000005Z"`,
+		},
+		{
+			name:       "Marshal unset Time",
+			initial:    Time{},
+			wantOutput: "null",
+		},
+		{
+			name:    "Marshal Time with year after 9999",
+			initial: Time{}.Set(time.Date(10000, 1, 1, 0, 0, 0, 0, time.UTC)),
+			wantErr: true,
+		},
+		{
+			name:    "Marshal Time with negative year",
+			initial: Time{}.Set(time.Date(-1, 1, 1, 0, 0, 0, 0, time.UTC)),
+			wantErr: true,
+		},
+		{
+			name:      "Unmarshal set Time",
+			jsonInput: `"2024-12-30T15:04:05.0000005Z"`,
+			wantTime:  ts,
+			wantIsSet: true,
+		},
+		{
+			name:      "Unmarshal set Time with offset",
+			jsonInput: `"2024-12-30T16:04:05.0000005+01:00"`,
+			wantTime:  ts,
+			wantIsSet: true,
+		},
+		{
+			name:      "Unmarshal null Time",
+			jsonInput: "null",
+			wantIsSet: false,
+		},
+		{
+			name:      "Unmarshal non RFC 3339 Time",
+			jsonInput: `"Dec 30 2024"`,
+			wantErr:   true,
+		},
+		{
+			name:      "Unmarshal number Time",
+			jsonInput: "42",
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		if tt.jsonInput == "" {
+			// Test MarshalJSON
+			jsonBytes, err := tt.initial.MarshalJSON()
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("TestMarshalling(%s)(v1): got err == nil, want err != nil", tt.name)
+				}
+				if _, err := json.Marshal(tt.initial); err == nil {
+					t.Errorf("TestMarshalling(%s)(v2): got err == nil, want err != nil", tt.name)
+				}
+				continue
+			}
+			if err != nil {
+				t.Fatalf("TestMarshalling(%s) failed: %v", tt.name, err)
+			}
+			gotOutput := string(jsonBytes)
+			if gotOutput != tt.wantOutput {
+				t.Errorf("TestMarshalling(%s) = %v, want %v", tt.name, gotOutput, tt.wantOutput)
+			}
+			continue
+		}
+
+		// Test UnmarshalJSON
+		var v Time
+		err := v.UnmarshalJSON([]byte(tt.jsonInput))
+		switch {
+		case err == nil && tt.wantErr:
+			t.Errorf("TestMarshalling(%s)(v1): got err == nil, want err != nil", tt.name)
+		case err != nil && !tt.wantErr:
+			t.Errorf("TestMarshalling(%s)(v1): got err == %s, want err == nil", tt.name, err)
+		}
+		if got := v.V(); !got.Equal(tt.wantTime) {
+			t.Errorf("TestMarshalling(%s)(v1): V() = %v, want %v", tt.name, got, tt.wantTime)
+		}
+		if got := v.IsSet(); got != tt.wantIsSet {
+			t.Errorf("TestMarshalling(%s)(v1): IsSet() = %v, want %v", tt.name, got, tt.wantIsSet)
+		}
+
+		// Test v2
+		var v2 Time
+		dec := jsontext.NewDecoder(bytes.NewReader([]byte(tt.jsonInput)))
+		err = v2.UnmarshalJSONV2(dec, json.DefaultOptionsV2())
+		switch {
+		case err == nil && tt.wantErr:
+			t.Errorf("TestMarshalling(%s)(v2): got err == nil, want err != nil", tt.name)
+		case err != nil && !tt.wantErr:
+			t.Errorf("TestMarshalling(%s)(v2): got err == %s, want err == nil", tt.name, err)
+		}
+		if got := v2.V(); !got.Equal(tt.wantTime) {
+			t.Errorf("TestMarshalling(%s)(v2): V() = %v, want %v", tt.name, got, tt.wantTime)
+		}
+		if got := v2.IsSet(); got != tt.wantIsSet {
+			t.Errorf("TestMarshalling(%s)(v2): IsSet() = %v, want %v", tt.name, got, tt.wantIsSet)
+		}
+	}
+}
+
+func TestDuration(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		operation    func() Duration
+		wantDuration time.Duration
+		wantIsSet    bool
+	}{
+		{
+			name: "Set Duration",
+			operation: func() Duration {
+				var v Duration
+				return v.Set(5 * time.Second)
+			},
+			wantDuration: 5 * time.Second,
+			wantIsSet:    true,
+		},
+		{
+			name: "Unset Duration",
+			operation: func() Duration {
+				var v Duration
+				v = v.Set(5 * time.Second)
+				return v.Unset()
+			},
+			wantDuration: 0, // Default zero Duration
+			wantIsSet:    false,
+		},
+		{
+			name: "Default Duration",
+			operation: func() Duration {
+				return Duration{}
+			},
+			wantDuration: 0, // Default zero Duration
+			wantIsSet:    false,
+		},
+		{
+			name: "Set the zero Duration",
+			operation: func() Duration {
+				var v Duration
+				return v.Set(0)
+			},
+			wantDuration: 0,
+			wantIsSet:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		v := tt.operation()
+		if got := v.V(); got != tt.wantDuration {
+			t.Errorf("TestDuration(%s): V() = %v, want %v", tt.name, got, tt.wantDuration)
+		}
+		if got := v.IsSet(); got != tt.wantIsSet {
+			t.Errorf("TestDuration(%s): IsSet() = %v, want %v", tt.name, got, tt.wantIsSet)
+		}
+	}
+}
+
+func TestDurationMarshalling(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		initial      Duration
+		jsonInput    string
+		wantDuration time.Duration
+		wantIsSet    bool
+		wantOutput   string
+		wantErr      bool
+	}{
+		{
+			name:       "Marshal set Duration",
+			initial:    Duration{}.Set(90 * time.Second),
+			wantOutput: `"1m30s"`,
+		},
+		{
+			name:       "Marshal unset Duration",
+			initial:    Duration{},
+			wantOutput: "null",
+		},
+		{
+			name:         "Unmarshal duration string",
+			jsonInput:    `"5s"`,
+			wantDuration: 5 * time.Second,
+			wantIsSet:    true,
+		},
+		{
+			name:         "Unmarshal nanoseconds",
+			jsonInput:    "5000000000",
+			wantDuration: 5 * time.Second,
+			wantIsSet:    true,
+		},
+		{
+			name:         "Unmarshal zero nanoseconds",
+			jsonInput:    "0",
+			wantDuration: 0,
+			wantIsSet:    true,
+		},
+		{
+			name:      "Unmarshal null Duration",
+			jsonInput: "null",
+			wantIsSet: false,
+		},
+		{
+			name:      "Unmarshal bad duration string",
+			jsonInput: `"5 parsecs"`,
+			wantErr:   true,
+		},
+		{
+			name:      "Unmarshal fractional nanoseconds",
+			jsonInput: "1.5",
+			wantErr:   true,
+		},
+		{
+			name:      "Unmarshal bool Duration",
+			jsonInput: "true",
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		if tt.jsonInput == "" {
+			// Test MarshalJSON
+			jsonBytes, err := tt.initial.MarshalJSON()
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("TestMarshalling(%s)(v1): got err == nil, want err != nil", tt.name)
+				}
+				if _, err := json.Marshal(tt.initial); err == nil {
+					t.Errorf("TestMarshalling(%s)(v2): got err == nil, want err != nil", tt.name)
+				}
+				continue
+			}
+			if err != nil {
+				t.Fatalf("TestMarshalling(%s) failed: %v", tt.name, err)
+			}
+			gotOutput := string(jsonBytes)
+			if gotOutput != tt.wantOutput {
+				t.Errorf("TestMarshalling(%s) = %v, want %v", tt.name, gotOutput, tt.wantOutput)
+			}
+			continue
+		}
+
+		// Test UnmarshalJSON
+		var v Duration
+		err := v.UnmarshalJSON([]byte(tt.jsonInput))
+		switch {
+		case err == nil && tt.wantErr:
+			t.Errorf("TestMarshalling(%s)(v1): got err == nil, want err != nil", tt.name)
+		case err != nil && !tt.wantErr:
+			t.Errorf("TestMarshalling(%s)(v1): got err == %s, want err == nil", tt.name, err)
+		}
+		if got := v.V(); got != tt.wantDuration {
+			t.Errorf("TestMarshalling(%s)(v1): V() = %v, want %v", tt.name, got, tt.wantDuration)
+		}
+		if got := v.IsSet(); got != tt.wantIsSet {
+			t.Errorf("TestMarshalling(%s)(v1): IsSet() = %v, want %v", tt.name, got, tt.wantIsSet)
+		}
+
+		// Test v2
+		var v2 Duration
+		dec := jsontext.NewDecoder(bytes.NewReader([]byte(tt.jsonInput)))
+		err = v2.UnmarshalJSONV2(dec, json.DefaultOptionsV2())
+		switch {
+		case err == nil && tt.wantErr:
+			t.Errorf("TestMarshalling(%s)(v2): got err == nil, want err != nil", tt.name)
+		case err != nil && !tt.wantErr:
+			t.Errorf("TestMarshalling(%s)(v2): got err == %s, want err == nil", tt.name, err)
+		}
+		if got := v2.V(); got != tt.wantDuration {
+			t.Errorf("TestMarshalling(%s)(v2): V() = %v, want %v", tt.name, got, tt.wantDuration)
+		}
+		if got := v2.IsSet(); got != tt.wantIsSet {
+			t.Errorf("TestMarshalling(%s)(v2): IsSet() = %v, want %v", tt.name, got, tt.wantIsSet)
+		}
+	}
+}
+
+func TestTimeText(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		text     string
+		want     Time
+		wantErr  bool
+		wantText string
+	}{
+		{name: "utc", text: "2024-12-30T15:04:05Z", want: Time{}.Set(time.Date(2024, 12, 30, 15, 4, 5, 0, time.UTC)), wantText: "2024-12-30T15:04:05Z"},
+		{name: "not rfc 3339", text: "2024-12-30", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		var v Time
+		err := v.UnmarshalText([]byte(tt.text))
+		switch {
+		case err == nil && tt.wantErr:
+			t.Errorf("TestTimeText(%s): got err == nil, want err != nil", tt.name)
+			continue
+		case err != nil && !tt.wantErr:
+			t.Errorf("TestTimeText(%s): got err == %s, want err == nil", tt.name, err)
+			continue
+		case err != nil:
+			if v.IsSet() {
+				t.Errorf("TestTimeText(%s): IsSet() = true after error, want false", tt.name)
+			}
+			continue
+		}
+		if !v.V().Equal(tt.want.V()) || v.IsSet() != tt.want.IsSet() {
+			t.Errorf("TestTimeText(%s): got %v(set %v), want %v(set %v)", tt.name, v.V(), v.IsSet(), tt.want.V(), tt.want.IsSet())
+		}
+
+		text, err := v.MarshalText()
+		if err != nil {
+			t.Fatalf("TestTimeText(%s): MarshalText() failed: %v", tt.name, err)
+		}
+		if string(text) != tt.wantText {
+			t.Errorf("TestTimeText(%s): MarshalText() = %q, want %q", tt.name, text, tt.wantText)
+		}
+	}
+
+	text, err := Time{}.MarshalText()
+	if err != nil || len(text) != 0 {
+		t.Errorf("TestTimeText(unset): MarshalText() = %q, %v, want empty text", text, err)
+	}
+	b, err := Time{}.Set(time.Date(2024, 12, 30, 0, 0, 0, 0, time.UTC)).AppendText([]byte("x="))
+	if err != nil || string(b) != "x=2024-12-30T00:00:00Z" {
+		t.Errorf("TestTimeText(append): AppendText() = %q, %v, want %q", b, err, "x=2024-12-30T00:00:00Z")
+	}
+	if _, err := (Time{}).Set(time.Date(10000, 1, 1, 0, 0, 0, 0, time.UTC)).MarshalText(); err == nil {
+		t.Errorf("TestTimeText(year after 9999): MarshalText() succeeded, want error")
+	}
+}
+
+func TestDurationText(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		text     string
+		want     Duration
+		wantErr  bool
+		wantText string
+	}{
+		{name: "units", text: "1h30m", want: Duration{}.Set(90 * time.Minute), wantText: "1h30m0s"},
+		{name: "zero", text: "0", want: Duration{}.Set(0), wantText: "0s"},
+		{name: "no unit", text: "10", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		var v Duration
+		err := v.UnmarshalText([]byte(tt.text))
+		switch {
+		case err == nil && tt.wantErr:
+			t.Errorf("TestDurationText(%s): got err == nil, want err != nil", tt.name)
+			continue
+		case err != nil && !tt.wantErr:
+			t.Errorf("TestDurationText(%s): got err == %s, want err == nil", tt.name, err)
+			continue
+		case err != nil:
+			if v.IsSet() {
+				t.Errorf("TestDurationText(%s): IsSet() = true after error, want false", tt.name)
+			}
+			continue
+		}
+		if v != tt.want {
+			t.Errorf("TestDurationText(%s): got %v(set %v), want %v(set %v)", tt.name, v.V(), v.IsSet(), tt.want.V(), tt.want.IsSet())
+		}
+
+		text, err := v.MarshalText()
+		if err != nil {
+			t.Fatalf("TestDurationText(%s): MarshalText() failed: %v", tt.name, err)
+		}
+		if string(text) != tt.wantText {
+			t.Errorf("TestDurationText(%s): MarshalText() = %q, want %q", tt.name, text, tt.wantText)
+		}
+	}
+
+	text, err := Duration{}.MarshalText()
+	if err != nil || len(text) != 0 {
+		t.Errorf("TestDurationText(unset): MarshalText() = %q, %v, want empty text", text, err)
+	}
+	b, err := Duration{}.Set(time.Second).AppendText([]byte("x="))
+	if err != nil || string(b) != "x=1s" {
+		t.Errorf("TestDurationText(append): AppendText() = %q, %v, want %q", b, err, "x=1s")
+	}
+}
@@ -2,6 +2,7 @@ package isset
 
 import (
 	"fmt"
+	"strconv"
 
 	"github.com/go-json-experiment/json"
 	"github.com/go-json-experiment/json/jsontext"
@@ -37,6 +38,11 @@ func (i uintType[T]) IsSet() bool {
 	return i.isSet
 }
 
+// IsZero reports if the value is unset.
+func (i uintType[T]) IsZero() bool {
+	return !i.isSet
+}
+
 // Set sets the value and marks it as set.
 func (i uintType[T]) Set(val T) uintType[T] {
 	i.v = val
@@ -54,19 +60,66 @@ func (i uintType[T]) Unset() uintType[T] {
 
 // MarshalJSON implements the json.Marshaler interface.
 func (i uintType[T]) MarshalJSON() ([]byte, error) {
-	if !i.isSet {
-		return []byte{}, nil
+	return i.AppendJSON(make([]byte, 0, 22))
+}
+
+// AppendJSON appends the JSON encoding returned by MarshalJSON to dst. It lets encoders write the
+// value into their own buffer without allocating.
+func (i uintType[T]) AppendJSON(dst []byte) ([]byte, error) {
+	return i.appendJSON(dst, getDefaults())
+}
+
+// appendJSON is AppendJSON with the options in o.
+func (i uintType[T]) appendJSON(dst []byte, o *options) ([]byte, error) {
+	if o.writeNull(i.isSet) {
+		return append(dst, "null"...), nil
+	}
+	if o.stringify {
+		dst = strconv.AppendUint(append(dst, '"'), uint64(i.v), 10)
+		return append(dst, '"'), nil
 	}
-	return json.Marshal(i.v)
+	return strconv.AppendUint(dst, uint64(i.v), 10), nil
 }
 
 // MarshalJSONV2 implements the json.MarshalerV2 interface.
 func (i uintType[T]) MarshalJSONV2(enc *jsontext.Encoder, opts json.Options) error {
+	return i.marshalJSONV2(enc, opts, getDefaults())
+}
+
+func (i uintType[T]) marshalJSONV2(enc *jsontext.Encoder, opts json.Options, o *options) error {
+	if o.writeNull(i.isSet) {
+		return enc.WriteToken(jsontext.Null)
+	}
+	format, err := formatOf(opts, enc.StackDepth())
+	if err != nil {
+		return err
+	}
+	switch format {
+	case "":
+	case "hex":
+		return enc.WriteToken(jsontext.String(string(i.appendHex(nil))))
+	default:
+		return invalidFormatError(numberTypeName[T](), format)
+	}
+	if o.stringify || stringifyNumbers(opts) {
+		return enc.WriteToken(jsontext.String(strconv.FormatUint(uint64(i.v), 10)))
+	}
 	return enc.WriteToken(jsontext.Uint(uint64(i.v)))
 }
 
+// appendHex appends the value as written for the `format:hex` struct tag option.
+func (i uintType[T]) appendHex(b []byte) []byte {
+	u, neg := uint64(i.v), false
+	return appendHex(b, u, neg)
+}
+
 // UnmarshalJSON implements the json.Unmarshaler interface.
 func (i *uintType[T]) UnmarshalJSON(data []byte) error {
+	return i.unmarshalJSON(data, getDefaults())
+}
+
+// unmarshalJSON is UnmarshalJSON with the options in o.
+func (i *uintType[T]) unmarshalJSON(data []byte, o *options) error {
 	if bytesToStr(data) == "null" {
 		var zero T
 		i.isSet = false
@@ -74,17 +127,57 @@ func (i *uintType[T]) UnmarshalJSON(data []byte) error {
 		return nil
 	}
 
-	i.isSet = true
-	var t T
-	if err := json.Unmarshal(data, &t); err != nil {
-		return err
+	switch jsontext.Value(data).Kind() {
+	case '0':
+		t, err := parseUint[T](bytesToStr(data), 10)
+		if err != nil {
+			return decodeErrorV1(numberTypeName[T](), '0', data, err)
+		}
+		i.v = t
+		i.isSet = true
+		return nil
+	case '"':
+		if o.stringify || o.lenient {
+			err := unquoteV1(data, func(s string) error {
+				return i.setString(s, o.lenient)
+			})
+			if err != nil {
+				return decodeErrorV1(numberTypeName[T](), '0', data, err)
+			}
+			return nil
+		}
 	}
-	i.v = t
-	return nil
+	return decodeErrorV1(numberTypeName[T](), '0', data, nil)
 }
 
 // UnmarshalJSONV2 implements the json.UnmarshalerV2 interface.
 func (v *uintType[T]) UnmarshalJSONV2(dec *jsontext.Decoder, opts json.Options) error {
+	return v.unmarshalJSONV2(dec, opts, getDefaults())
+}
+
+func (v *uintType[T]) unmarshalJSONV2(dec *jsontext.Decoder, opts json.Options, o *options) error {
+	format, err := formatOf(opts, dec.StackDepth())
+	if err != nil {
+		return err
+	}
+	if format != "" && format != "hex" {
+		return invalidFormatError(numberTypeName[T](), format)
+	}
+
+	// Numbers are parsed from the raw value, as Token.String allocates a string for them.
+	if dec.PeekKind() == '0' && format != "hex" {
+		val, err := dec.ReadValue()
+		if err != nil {
+			return err
+		}
+		n, err := parseUint[T](bytesToStr(val), 10)
+		if err != nil {
+			return decodeError(dec, numberTypeName[T](), '0', '0', err)
+		}
+		*v = v.Set(n)
+		return nil
+	}
+
 	t, err := dec.ReadToken()
 	if err != nil {
 		return err
@@ -95,10 +188,71 @@ func (v *uintType[T]) UnmarshalJSONV2(dec *jsontext.Decoder, opts json.Options)
 		v.isSet = false
 		v.v = 0
 		return nil
-	case '0':
-		v.isSet = true
-		v.v = T(t.Uint())
+	case '"':
+		if format == "hex" {
+			n, err := parseHex(t.String(), parseUint[T])
+			if err != nil {
+				return decodeError(dec, numberTypeName[T](), '"', '"', err)
+			}
+			*v = v.Set(n)
+			return nil
+		}
+		if o.stringify || o.lenient || stringifyNumbers(opts) {
+			if err := v.setString(t.String(), o.lenient); err != nil {
+				return decodeError(dec, numberTypeName[T](), '0', '"', err)
+			}
+			return nil
+		}
+	}
+	if format == "hex" {
+		return decodeError(dec, numberTypeName[T](), '"', t.Kind(), nil)
+	}
+	return decodeError(dec, numberTypeName[T](), '0', t.Kind(), nil)
+}
+
+// setString sets v from the JSON string s, which holds a JSON number when numbers are stringified.
+// If lenient is set, s may be any value that setLenient accepts.
+func (v *uintType[T]) setString(s string, lenient bool) error {
+	if lenient {
+		return v.setLenient(s)
+	}
+	if !isNumber(s) {
+		return fmt.Errorf("invalid number %q", s)
+	}
+	n, err := parseUint[T](s, 10)
+	if err != nil {
+		return err
+	}
+	*v = v.Set(n)
+	return nil
+}
+
+// MarshalText implements the encoding.TextMarshaler interface. An unset value marshals to empty text.
+func (i uintType[T]) MarshalText() ([]byte, error) {
+	return i.AppendText(nil)
+}
+
+// AppendText implements the encoding.TextAppender interface.
+func (i uintType[T]) AppendText(b []byte) ([]byte, error) {
+	if !i.isSet {
+		return b, nil
+	}
+	return strconv.AppendUint(b, uint64(i.v), 10), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface. It accepts the syntax of Go integer
+// literals, including base prefixes such as 0x and underscores, and marks the value as set on success.
+// Empty text, which MarshalText writes for an unset value, makes the value unset.
+func (i *uintType[T]) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*i = i.Unset()
 		return nil
 	}
-	return fmt.Errorf("expected a JSON number, got %T", t.Kind())
+	n, err := strconv.ParseUint(bytesToStr(text), 0, bitSize[T]())
+	if err != nil {
+		return err
+	}
+	i.v = T(n)
+	i.isSet = true
+	return nil
 }
@@ -2,6 +2,7 @@ package isset
 
 import (
 	"bytes"
+	"math"
 	"testing"
 
 	"github.com/go-json-experiment/json"
@@ -91,7 +92,7 @@ func TestUintMarshalling(t *testing.T) {
 			jsonInput:  "",
 			wantUint:   0,
 			wantIsSet:  false,
-			wantOutput: "",
+			wantOutput: "null",
 		},
 		{
 			name:       "Unmarshal set Uint",
@@ -152,3 +153,84 @@ func TestUintMarshalling(t *testing.T) {
 		}
 	}
 }
+
+func TestUint16Text(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		text     string
+		want     Uint16
+		wantErr  bool
+		wantText string
+	}{
+		{name: "decimal", text: "42", want: Uint16{}.Set(42), wantText: "42"},
+		{name: "hex", text: "0xFFFF", want: Uint16{}.Set(65535), wantText: "65535"},
+		{name: "underscores", text: "1_000", want: Uint16{}.Set(1000), wantText: "1000"},
+		{name: "out of range", text: "65536", wantErr: true},
+		{name: "negative", text: "-1", wantErr: true},
+		{name: "empty is unset", text: ""},
+	}
+
+	for _, tt := range tests {
+		var v Uint16
+		err := v.UnmarshalText([]byte(tt.text))
+		switch {
+		case err == nil && tt.wantErr:
+			t.Errorf("TestUint16Text(%s): got err == nil, want err != nil", tt.name)
+			continue
+		case err != nil && !tt.wantErr:
+			t.Errorf("TestUint16Text(%s): got err == %s, want err == nil", tt.name, err)
+			continue
+		case err != nil:
+			if v.IsSet() {
+				t.Errorf("TestUint16Text(%s): IsSet() = true after error, want false", tt.name)
+			}
+			continue
+		}
+		if v != tt.want {
+			t.Errorf("TestUint16Text(%s): got %v(set %v), want %v(set %v)", tt.name, v.V(), v.IsSet(), tt.want.V(), tt.want.IsSet())
+		}
+
+		text, err := v.MarshalText()
+		if err != nil {
+			t.Fatalf("TestUint16Text(%s): MarshalText() failed: %v", tt.name, err)
+		}
+		if string(text) != tt.wantText {
+			t.Errorf("TestUint16Text(%s): MarshalText() = %q, want %q", tt.name, text, tt.wantText)
+		}
+	}
+
+	text, err := Uint16{}.MarshalText()
+	if err != nil || len(text) != 0 {
+		t.Errorf("TestUint16Text(unset): MarshalText() = %q, %v, want empty text", text, err)
+	}
+	b, err := Uint16{}.Set(7).AppendText([]byte("x="))
+	if err != nil || string(b) != "x=7" {
+		t.Errorf("TestUint16Text(append): AppendText() = %q, %v, want %q", b, err, "x=7")
+	}
+}
+
+func TestUint64Stringify(t *testing.T) {
+	t.Parallel()
+
+	v := struct {
+		ID Uint64 `json:",string"`
+	}{ID: Uint64{}.Set(math.MaxUint64)}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("TestUint64Stringify: Marshal() failed: %v", err)
+	}
+	if want := `{"ID":"18446744073709551615"}`; string(b) != want {
+		t.Errorf("TestUint64Stringify: Marshal() = %s, want %s", b, want)
+	}
+
+	v.ID = Uint64{}
+	if err := json.Unmarshal(b, &v); err != nil || v.ID.V() != math.MaxUint64 {
+		t.Errorf("TestUint64Stringify: Unmarshal() = %v, got %v, want %v", err, v.ID.V(), uint64(math.MaxUint64))
+	}
+	if err := json.Unmarshal([]byte(`{"ID":"-1"}`), &v); err == nil {
+		t.Errorf("TestUint64Stringify: Unmarshal(\"-1\") succeeded, want error")
+	}
+}
@@ -0,0 +1,289 @@
+package isset
+
+import (
+	"encoding"
+	"encoding/xml"
+	"strconv"
+	"strings"
+)
+
+// This file implements the xml.Marshaler, xml.Unmarshaler, xml.MarshalerAttr and xml.UnmarshalerAttr
+// interfaces with the text encoding of MarshalText and UnmarshalText. An unset value writes no element
+// and no attribute, and encoding/xml does not call the methods for an absent element or attribute, so the
+// value stays unset. The text is trimmed of surrounding white space as encoding/xml does for numbers,
+// except for String, Of and Nullable, which get the text as is.
+//
+// A null Nullable is written as an element with xsi:nil="true", which decodes back to null. It writes no
+// attribute, as XML attributes have no null form.
+
+// xsiNamespace is the namespace of the xsi:nil attribute.
+const xsiNamespace = "http://www.w3.org/2001/XMLSchema-instance"
+
+// MarshalXML implements the xml.Marshaler interface. An unset value writes nothing.
+func (i Bool) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return marshalXML(i, i.IsSet(), e, start)
+}
+
+// MarshalXMLAttr implements the xml.MarshalerAttr interface. An unset value writes no attribute.
+func (i Bool) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttr(i, i.IsSet(), name)
+}
+
+// UnmarshalXML implements the xml.Unmarshaler interface.
+func (i *Bool) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXML(i, true, d, start)
+}
+
+// UnmarshalXMLAttr implements the xml.UnmarshalerAttr interface.
+func (i *Bool) UnmarshalXMLAttr(attr xml.Attr) error {
+	return unmarshalXMLAttr(i, true, attr)
+}
+
+// MarshalXML implements the xml.Marshaler interface. An unset value writes nothing.
+func (i String) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return marshalXML(i, i.IsSet(), e, start)
+}
+
+// MarshalXMLAttr implements the xml.MarshalerAttr interface. An unset value writes no attribute.
+func (i String) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttr(i, i.IsSet(), name)
+}
+
+// UnmarshalXML implements the xml.Unmarshaler interface.
+func (i *String) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXML(i, false, d, start)
+}
+
+// UnmarshalXMLAttr implements the xml.UnmarshalerAttr interface.
+func (i *String) UnmarshalXMLAttr(attr xml.Attr) error {
+	return unmarshalXMLAttr(i, false, attr)
+}
+
+// MarshalXML implements the xml.Marshaler interface. An unset value writes nothing.
+func (i intType[T]) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return marshalXML(i, i.IsSet(), e, start)
+}
+
+// MarshalXMLAttr implements the xml.MarshalerAttr interface. An unset value writes no attribute.
+func (i intType[T]) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttr(i, i.IsSet(), name)
+}
+
+// UnmarshalXML implements the xml.Unmarshaler interface.
+func (i *intType[T]) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXML(i, true, d, start)
+}
+
+// UnmarshalXMLAttr implements the xml.UnmarshalerAttr interface.
+func (i *intType[T]) UnmarshalXMLAttr(attr xml.Attr) error {
+	return unmarshalXMLAttr(i, true, attr)
+}
+
+// MarshalXML implements the xml.Marshaler interface. An unset value writes nothing.
+func (i uintType[T]) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return marshalXML(i, i.IsSet(), e, start)
+}
+
+// MarshalXMLAttr implements the xml.MarshalerAttr interface. An unset value writes no attribute.
+func (i uintType[T]) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttr(i, i.IsSet(), name)
+}
+
+// UnmarshalXML implements the xml.Unmarshaler interface.
+func (i *uintType[T]) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXML(i, true, d, start)
+}
+
+// UnmarshalXMLAttr implements the xml.UnmarshalerAttr interface.
+func (i *uintType[T]) UnmarshalXMLAttr(attr xml.Attr) error {
+	return unmarshalXMLAttr(i, true, attr)
+}
+
+// MarshalXML implements the xml.Marshaler interface. An unset value writes nothing.
+func (i floatType[T]) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return marshalXML(i, i.IsSet(), e, start)
+}
+
+// MarshalXMLAttr implements the xml.MarshalerAttr interface. An unset value writes no attribute.
+func (i floatType[T]) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttr(i, i.IsSet(), name)
+}
+
+// UnmarshalXML implements the xml.Unmarshaler interface.
+func (i *floatType[T]) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXML(i, true, d, start)
+}
+
+// UnmarshalXMLAttr implements the xml.UnmarshalerAttr interface.
+func (i *floatType[T]) UnmarshalXMLAttr(attr xml.Attr) error {
+	return unmarshalXMLAttr(i, true, attr)
+}
+
+// MarshalXML implements the xml.Marshaler interface. An unset value writes nothing.
+func (i Time) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return marshalXML(i, i.IsSet(), e, start)
+}
+
+// MarshalXMLAttr implements the xml.MarshalerAttr interface. An unset value writes no attribute.
+func (i Time) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttr(i, i.IsSet(), name)
+}
+
+// UnmarshalXML implements the xml.Unmarshaler interface.
+func (i *Time) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXML(i, true, d, start)
+}
+
+// UnmarshalXMLAttr implements the xml.UnmarshalerAttr interface.
+func (i *Time) UnmarshalXMLAttr(attr xml.Attr) error {
+	return unmarshalXMLAttr(i, true, attr)
+}
+
+// MarshalXML implements the xml.Marshaler interface. An unset value writes nothing.
+func (i Duration) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return marshalXML(i, i.IsSet(), e, start)
+}
+
+// MarshalXMLAttr implements the xml.MarshalerAttr interface. An unset value writes no attribute.
+func (i Duration) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttr(i, i.IsSet(), name)
+}
+
+// UnmarshalXML implements the xml.Unmarshaler interface.
+func (i *Duration) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXML(i, true, d, start)
+}
+
+// UnmarshalXMLAttr implements the xml.UnmarshalerAttr interface.
+func (i *Duration) UnmarshalXMLAttr(attr xml.Attr) error {
+	return unmarshalXMLAttr(i, true, attr)
+}
+
+// MarshalXML implements the xml.Marshaler interface. An unset value writes nothing.
+func (i Bytes) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return marshalXML(i, i.IsSet(), e, start)
+}
+
+// MarshalXMLAttr implements the xml.MarshalerAttr interface. An unset value writes no attribute.
+func (i Bytes) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttr(i, i.IsSet(), name)
+}
+
+// UnmarshalXML implements the xml.Unmarshaler interface.
+func (i *Bytes) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXML(i, true, d, start)
+}
+
+// UnmarshalXMLAttr implements the xml.UnmarshalerAttr interface.
+func (i *Bytes) UnmarshalXMLAttr(attr xml.Attr) error {
+	return unmarshalXMLAttr(i, true, attr)
+}
+
+// MarshalXML implements the xml.Marshaler interface. An unset value writes nothing.
+func (i Of[T]) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return marshalXML(i, i.IsSet(), e, start)
+}
+
+// MarshalXMLAttr implements the xml.MarshalerAttr interface. An unset value writes no attribute.
+func (i Of[T]) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttr(i, i.IsSet(), name)
+}
+
+// UnmarshalXML implements the xml.Unmarshaler interface.
+func (i *Of[T]) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXML(i, false, d, start)
+}
+
+// UnmarshalXMLAttr implements the xml.UnmarshalerAttr interface.
+func (i *Of[T]) UnmarshalXMLAttr(attr xml.Attr) error {
+	return unmarshalXMLAttr(i, false, attr)
+}
+
+// MarshalXML implements the xml.Marshaler interface. An absent value writes nothing and a null value
+// writes an empty element with xsi:nil="true".
+func (i Nullable[T]) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if i.state == nullNull {
+		start.Attr = append(start.Attr,
+			xml.Attr{Name: xml.Name{Local: "xmlns:xsi"}, Value: xsiNamespace},
+			xml.Attr{Name: xml.Name{Local: "xsi:nil"}, Value: "true"},
+		)
+		return e.EncodeElement("", start)
+	}
+	return marshalXML(i, i.IsSet(), e, start)
+}
+
+// MarshalXMLAttr implements the xml.MarshalerAttr interface. A value that is not set writes no attribute.
+func (i Nullable[T]) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttr(i, i.IsSet(), name)
+}
+
+// UnmarshalXML implements the xml.Unmarshaler interface. An element with xsi:nil="true" makes the value
+// null.
+func (i *Nullable[T]) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	for _, a := range start.Attr {
+		// The decoder replaces a declared prefix with its namespace and keeps an undeclared one.
+		if (a.Name.Space == xsiNamespace || a.Name.Space == "xsi") && a.Name.Local == "nil" {
+			null, err := strconv.ParseBool(strings.TrimSpace(a.Value))
+			if err != nil {
+				return err
+			}
+			if null {
+				*i = i.SetNull()
+				return d.Skip()
+			}
+		}
+	}
+	return unmarshalXML(i, false, d, start)
+}
+
+// UnmarshalXMLAttr implements the xml.UnmarshalerAttr interface.
+func (i *Nullable[T]) UnmarshalXMLAttr(attr xml.Attr) error {
+	return unmarshalXMLAttr(i, false, attr)
+}
+
+// marshalXML writes the element start with the text of m if set is true, and nothing otherwise.
+func marshalXML(m encoding.TextMarshaler, set bool, e *xml.Encoder, start xml.StartElement) error {
+	if !set {
+		return nil
+	}
+	text, err := m.MarshalText()
+	if err != nil {
+		return err
+	}
+	return e.EncodeElement(string(text), start)
+}
+
+// marshalXMLAttr returns the attribute name with the text of m if set is true, and otherwise the zero
+// xml.Attr, which encoding/xml omits.
+func marshalXMLAttr(m encoding.TextMarshaler, set bool, name xml.Name) (xml.Attr, error) {
+	if !set {
+		return xml.Attr{}, nil
+	}
+	text, err := m.MarshalText()
+	if err != nil {
+		return xml.Attr{}, err
+	}
+	return xml.Attr{Name: name, Value: string(text)}, nil
+}
+
+// unmarshalXML decodes the character data of the element start into u, trimmed of white space if trim
+// is true.
+func unmarshalXML(u encoding.TextUnmarshaler, trim bool, d *xml.Decoder, start xml.StartElement) error {
+	var s string
+	if err := d.DecodeElement(&s, &start); err != nil {
+		return err
+	}
+	if trim {
+		s = strings.TrimSpace(s)
+	}
+	return u.UnmarshalText([]byte(s))
+}
+
+// unmarshalXMLAttr decodes the value of attr into u, trimmed of white space if trim is true.
+func unmarshalXMLAttr(u encoding.TextUnmarshaler, trim bool, attr xml.Attr) error {
+	s := attr.Value
+	if trim {
+		s = strings.TrimSpace(s)
+	}
+	return u.UnmarshalText([]byte(s))
+}
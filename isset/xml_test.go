@@ -0,0 +1,158 @@
+package isset
+
+import (
+	"encoding/xml"
+	"net/netip"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type xmlFeed struct {
+	XMLName xml.Name             `xml:"feed"`
+	ID      Uint64               `xml:"id,attr"`
+	Lang    String               `xml:"lang,attr"`
+	Title   String               `xml:"title"`
+	Count   Int16                `xml:"count"`
+	Score   Float64              `xml:"score"`
+	Live    Bool                 `xml:"live"`
+	Updated Time                 `xml:"updated"`
+	TTL     Duration             `xml:"ttl"`
+	Sig     Bytes                `xml:"sig"`
+	Addr    Of[netip.Addr]       `xml:"addr"`
+	Parent  Nullable[netip.Addr] `xml:"parent"`
+	Via     Nullable[netip.Addr] `xml:"via,attr"`
+}
+
+func TestXML(t *testing.T) {
+	t.Parallel()
+
+	addr := netip.MustParseAddr("10.0.0.1")
+
+	tests := []struct {
+		name string
+		in   xmlFeed
+		want string
+	}{
+		{name: "Unset", want: `<feed></feed>`},
+		{
+			name: "Zero values",
+			in:   xmlFeed{ID: Uint64{}.Set(0), Title: String{}.Set(""), Live: Bool{}.Set(false)},
+			want: `<feed id="0"><title></title><live>false</live></feed>`,
+		},
+		{
+			name: "Values",
+			in: xmlFeed{
+				ID:      Uint64{}.Set(7),
+				Lang:    String{}.Set("en"),
+				Title:   String{}.Set("a < b"),
+				Count:   Int16{}.Set(-3),
+				Score:   Float64{}.Set(0.5),
+				Live:    Bool{}.Set(true),
+				Updated: Time{}.Set(time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)),
+				TTL:     Duration{}.Set(time.Minute),
+				Sig:     Bytes{}.Set([]byte{1, 2}),
+				Addr:    Of[netip.Addr]{}.Set(addr),
+				Parent:  Nullable[netip.Addr]{}.Set(addr),
+				Via:     Nullable[netip.Addr]{}.Set(addr),
+			},
+			want: `<feed id="7" lang="en" via="10.0.0.1"><title>a &lt; b</title><count>-3</count><score>0.5</score>` +
+				`<live>true</live><updated>2025-01-02T03:04:05Z</updated><ttl>1m0s</ttl><sig>AQI=</sig>` +
+				`<addr>10.0.0.1</addr><parent>10.0.0.1</parent></feed>`,
+		},
+		{
+			name: "Null",
+			in:   xmlFeed{Parent: Nullable[netip.Addr]{}.SetNull(), Via: Nullable[netip.Addr]{}.SetNull()},
+			want: `<feed><parent xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:nil="true"></parent></feed>`,
+		},
+	}
+
+	for _, tt := range tests {
+		b, err := xml.Marshal(tt.in)
+		if err != nil {
+			t.Errorf("TestXML(%s): Marshal() failed: %v", tt.name, err)
+			continue
+		}
+		if string(b) != tt.want {
+			t.Errorf("TestXML(%s): Marshal() = %s, want %s", tt.name, b, tt.want)
+		}
+
+		var got xmlFeed
+		if err := xml.Unmarshal(b, &got); err != nil {
+			t.Errorf("TestXML(%s): Unmarshal() failed: %v", tt.name, err)
+			continue
+		}
+		// A null attribute is not written, so it decodes as absent.
+		want := tt.in
+		want.XMLName = xml.Name{Local: "feed"}
+		if want.Via.IsNull() {
+			want.Via = want.Via.Unset()
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("TestXML(%s): Unmarshal() = %+v, want %+v", tt.name, got, want)
+		}
+	}
+}
+
+func TestXMLUnmarshal(t *testing.T) {
+	t.Parallel()
+
+	data := `<feed id=" 9 " lang=" en ">
+		<title> spaced </title>
+		<count>
+			12
+		</count>
+		<live>1</live>
+		<sig>
+			AQI=
+		</sig>
+		<parent xsi:nil="1"/>
+	</feed>`
+
+	var got xmlFeed
+	if err := xml.Unmarshal([]byte(data), &got); err != nil {
+		t.Fatalf("TestXMLUnmarshal: Unmarshal() failed: %v", err)
+	}
+
+	want := xmlFeed{
+		XMLName: xml.Name{Local: "feed"},
+		ID:      Uint64{}.Set(9),
+		Lang:    String{}.Set(" en "),
+		Title:   String{}.Set(" spaced "),
+		Count:   Int16{}.Set(12),
+		Live:    Bool{}.Set(true),
+		Sig:     Bytes{}.Set([]byte{1, 2}),
+		Parent:  Nullable[netip.Addr]{}.SetNull(),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("TestXMLUnmarshal: got %+v, want %+v", got, want)
+	}
+}
+
+func TestXMLErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		data string
+	}{
+		{name: "Int16 overflow", data: `<feed><count>40000</count></feed>`},
+		{name: "Uint64 attribute", data: `<feed id="-1"></feed>`},
+		{name: "Bool", data: `<feed><live>yes</live></feed>`},
+		{name: "Float64", data: `<feed><score>high</score></feed>`},
+		{name: "Time", data: `<feed><updated>today</updated></feed>`},
+		{name: "Of", data: `<feed><addr>host</addr></feed>`},
+		{name: "invalid xsi:nil", data: `<feed><parent xsi:nil="maybe"></parent></feed>`},
+	}
+
+	for _, tt := range tests {
+		var got xmlFeed
+		if err := xml.Unmarshal([]byte(tt.data), &got); err == nil {
+			t.Errorf("TestXMLErrors(%s): Unmarshal() succeeded, want error", tt.name)
+		}
+	}
+
+	if _, err := xml.Marshal(struct{ P Of[point] }{P: Of[point]{}.Set(point{})}); err == nil {
+		t.Errorf("TestXMLErrors(Of without methods): Marshal() succeeded, want error")
+	}
+}
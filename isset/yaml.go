@@ -0,0 +1,254 @@
+package isset
+
+import (
+	"encoding/base64"
+	"time"
+)
+
+// This file implements the MarshalYAML and UnmarshalYAML methods in the form used by gopkg.in/yaml.v2,
+// which gopkg.in/yaml.v3 also honors. Neither needs the YAML package to be imported. A YAML null decodes
+// to an unset value and an unset value encodes as null, matching UnmarshalJSON and MarshalJSON.
+// Unset values are omitted by fields with the `omitempty` struct tag option, as the types implement IsZero.
+//
+// sigs.k8s.io/yaml converts YAML to JSON and uses the JSON methods instead.
+
+// MarshalYAML implements the yaml.Marshaler interface.
+func (i Bool) MarshalYAML() (any, error) {
+	if !i.isSet {
+		return nil, nil
+	}
+	return i.v, nil
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (i *Bool) UnmarshalYAML(unmarshal func(any) error) error {
+	v, ok, err := unmarshalYAML[bool](unmarshal)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		*i = i.Unset()
+		return nil
+	}
+	*i = i.Set(v)
+	return nil
+}
+
+// MarshalYAML implements the yaml.Marshaler interface.
+func (i String) MarshalYAML() (any, error) {
+	if !i.isSet {
+		return nil, nil
+	}
+	return i.v, nil
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (i *String) UnmarshalYAML(unmarshal func(any) error) error {
+	v, ok, err := unmarshalYAML[string](unmarshal)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		*i = i.Unset()
+		return nil
+	}
+	*i = i.Set(v)
+	return nil
+}
+
+// MarshalYAML implements the yaml.Marshaler interface.
+func (i intType[T]) MarshalYAML() (any, error) {
+	if !i.isSet {
+		return nil, nil
+	}
+	return i.v, nil
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (i *intType[T]) UnmarshalYAML(unmarshal func(any) error) error {
+	v, ok, err := unmarshalYAML[T](unmarshal)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		*i = i.Unset()
+		return nil
+	}
+	*i = i.Set(v)
+	return nil
+}
+
+// MarshalYAML implements the yaml.Marshaler interface.
+func (i uintType[T]) MarshalYAML() (any, error) {
+	if !i.isSet {
+		return nil, nil
+	}
+	return i.v, nil
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (i *uintType[T]) UnmarshalYAML(unmarshal func(any) error) error {
+	v, ok, err := unmarshalYAML[T](unmarshal)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		*i = i.Unset()
+		return nil
+	}
+	*i = i.Set(v)
+	return nil
+}
+
+// MarshalYAML implements the yaml.Marshaler interface.
+func (i floatType[T]) MarshalYAML() (any, error) {
+	if !i.isSet {
+		return nil, nil
+	}
+	return i.v, nil
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (i *floatType[T]) UnmarshalYAML(unmarshal func(any) error) error {
+	v, ok, err := unmarshalYAML[T](unmarshal)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		*i = i.Unset()
+		return nil
+	}
+	*i = i.Set(v)
+	return nil
+}
+
+// MarshalYAML implements the yaml.Marshaler interface.
+func (i Time) MarshalYAML() (any, error) {
+	if !i.isSet {
+		return nil, nil
+	}
+	return i.v, nil
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (i *Time) UnmarshalYAML(unmarshal func(any) error) error {
+	v, ok, err := unmarshalYAML[time.Time](unmarshal)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		*i = i.Unset()
+		return nil
+	}
+	*i = i.Set(v)
+	return nil
+}
+
+// MarshalYAML implements the yaml.Marshaler interface. The value is encoded as a Go duration string.
+func (i Duration) MarshalYAML() (any, error) {
+	if !i.isSet {
+		return nil, nil
+	}
+	return i.v.String(), nil
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface. It accepts Go duration strings.
+func (i *Duration) UnmarshalYAML(unmarshal func(any) error) error {
+	v, ok, err := unmarshalYAML[string](unmarshal)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		*i = i.Unset()
+		return nil
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return err
+	}
+	*i = i.Set(d)
+	return nil
+}
+
+// MarshalYAML implements the yaml.Marshaler interface. The value is encoded as a standard base64 string.
+func (i Bytes) MarshalYAML() (any, error) {
+	if !i.isSet {
+		return nil, nil
+	}
+	return base64.StdEncoding.EncodeToString(i.v), nil
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface. It accepts standard base64 strings.
+func (i *Bytes) UnmarshalYAML(unmarshal func(any) error) error {
+	v, ok, err := unmarshalYAML[string](unmarshal)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		*i = i.Unset()
+		return nil
+	}
+	b, err := base64.StdEncoding.AppendDecode([]byte{}, []byte(v))
+	if err != nil {
+		return err
+	}
+	*i = i.Set(b)
+	return nil
+}
+
+// MarshalYAML implements the yaml.Marshaler interface.
+func (i Of[T]) MarshalYAML() (any, error) {
+	if !i.isSet {
+		return nil, nil
+	}
+	return i.v, nil
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (i *Of[T]) UnmarshalYAML(unmarshal func(any) error) error {
+	v, ok, err := unmarshalYAML[T](unmarshal)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		*i = i.Unset()
+		return nil
+	}
+	*i = i.Set(v)
+	return nil
+}
+
+// MarshalYAML implements the yaml.Marshaler interface. A value that is not set encodes as null.
+func (i Nullable[T]) MarshalYAML() (any, error) {
+	if i.state != nullSet {
+		return nil, nil
+	}
+	return i.v, nil
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface. A YAML null marks the value as null.
+func (i *Nullable[T]) UnmarshalYAML(unmarshal func(any) error) error {
+	v, ok, err := unmarshalYAML[T](unmarshal)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		*i = i.SetNull()
+		return nil
+	}
+	*i = i.Set(v)
+	return nil
+}
+
+// unmarshalYAML decodes a T with the unmarshal function passed to UnmarshalYAML. It decodes into a *T,
+// which YAML decoders leave nil for a null, to detect a null. ok is false if the value was null.
+func unmarshalYAML[T any](unmarshal func(any) error) (v T, ok bool, err error) {
+	var p *T
+	if err := unmarshal(&p); err != nil {
+		return v, false, err
+	}
+	if p == nil {
+		return v, false, nil
+	}
+	return *p, true, nil
+}
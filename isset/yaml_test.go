@@ -0,0 +1,114 @@
+package isset
+
+import (
+	stdjson "encoding/json"
+	"testing"
+	"time"
+)
+
+// yamlUnmarshaler is implemented by pointers to the types in this package.
+type yamlUnmarshaler interface {
+	UnmarshalYAML(func(any) error) error
+	MarshalYAML() (any, error)
+	IsSet() bool
+	IsZero() bool
+}
+
+// fakeYAML returns an unmarshal function like the one YAML decoders pass to UnmarshalYAML. It decodes
+// src with encoding/json, which, like YAML decoders, leaves a pointer nil for a null.
+func fakeYAML(src string) func(any) error {
+	return func(v any) error {
+		return stdjson.Unmarshal([]byte(src), v)
+	}
+}
+
+func TestYAML(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		dst       yamlUnmarshaler
+		src       string
+		wantIsSet bool
+		want      any
+		wantErr   bool
+	}{
+		{name: "Bool null", dst: new(Bool), src: "null"},
+		{name: "Bool set", dst: new(Bool), src: "false", wantIsSet: true, want: false},
+		{name: "String null", dst: new(String), src: "null"},
+		{name: "String set", dst: new(String), src: `""`, wantIsSet: true, want: ""},
+		{name: "Int set", dst: new(Int), src: "42", wantIsSet: true, want: 42},
+		{name: "Int8 out of range", dst: new(Int8), src: "300", wantErr: true},
+		{name: "Uint16 set", dst: new(Uint16), src: "8080", wantIsSet: true, want: uint16(8080)},
+		{name: "Float32 set", dst: new(Float32), src: "0.5", wantIsSet: true, want: float32(0.5)},
+		{name: "Duration set", dst: new(Duration), src: `"1m"`, wantIsSet: true, want: "1m0s"},
+		{name: "Duration bad", dst: new(Duration), src: `"soon"`, wantErr: true},
+		{name: "Time null", dst: new(Time), src: "null"},
+		{name: "Bytes set", dst: new(Bytes), src: `"aGk="`, wantIsSet: true, want: "aGk="},
+		{name: "Bytes empty", dst: new(Bytes), src: `""`, wantIsSet: true, want: ""},
+		{name: "Bytes bad", dst: new(Bytes), src: `"!"`, wantErr: true},
+		{name: "Of set", dst: new(Of[point]), src: `{"X":1}`, wantIsSet: true, want: point{X: 1}},
+	}
+
+	for _, tt := range tests {
+		err := tt.dst.UnmarshalYAML(fakeYAML(tt.src))
+		switch {
+		case err == nil && tt.wantErr:
+			t.Errorf("TestYAML(%s): got err == nil, want err != nil", tt.name)
+			continue
+		case err != nil && !tt.wantErr:
+			t.Errorf("TestYAML(%s): got err == %s, want err == nil", tt.name, err)
+			continue
+		case err != nil:
+			if tt.dst.IsSet() {
+				t.Errorf("TestYAML(%s): IsSet() = true after error, want false", tt.name)
+			}
+			continue
+		}
+		if got := tt.dst.IsSet(); got != tt.wantIsSet {
+			t.Errorf("TestYAML(%s): IsSet() = %v, want %v", tt.name, got, tt.wantIsSet)
+		}
+		if got := tt.dst.IsZero(); got != !tt.wantIsSet {
+			t.Errorf("TestYAML(%s): IsZero() = %v, want %v", tt.name, got, !tt.wantIsSet)
+		}
+
+		got, err := tt.dst.MarshalYAML()
+		if err != nil {
+			t.Fatalf("TestYAML(%s): MarshalYAML() failed: %v", tt.name, err)
+		}
+		if got != tt.want {
+			t.Errorf("TestYAML(%s): MarshalYAML() = %v(%T), want %v(%T)", tt.name, got, got, tt.want, tt.want)
+		}
+	}
+}
+
+func TestYAMLNullable(t *testing.T) {
+	t.Parallel()
+
+	var n Nullable[int]
+	if err := n.UnmarshalYAML(fakeYAML("null")); err != nil {
+		t.Fatalf("TestYAMLNullable: UnmarshalYAML(null) failed: %v", err)
+	}
+	if !n.IsNull() {
+		t.Errorf("TestYAMLNullable: IsNull() = false after null, want true")
+	}
+	if err := n.UnmarshalYAML(fakeYAML("7")); err != nil {
+		t.Fatalf("TestYAMLNullable: UnmarshalYAML(7) failed: %v", err)
+	}
+	if !n.IsSet() || n.V() != 7 {
+		t.Errorf("TestYAMLNullable: got %v(set %v), want 7(set true)", n.V(), n.IsSet())
+	}
+}
+
+func TestYAMLTime(t *testing.T) {
+	t.Parallel()
+
+	ts := time.Date(2024, 12, 30, 15, 4, 5, 0, time.UTC)
+	var v Time
+	if err := v.UnmarshalYAML(fakeYAML(`"2024-12-30T15:04:05Z"`)); err != nil {
+		t.Fatalf("TestYAMLTime: UnmarshalYAML() failed: %v", err)
+	}
+	if !v.IsSet() || !v.V().Equal(ts) {
+		t.Errorf("TestYAMLTime: got %v(set %v), want %v(set true)", v.V(), v.IsSet(), ts)
+	}
+}